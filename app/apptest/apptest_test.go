@@ -0,0 +1,144 @@
+package apptest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/app"
+	"github.com/Gong-Yang/g-micor/discover"
+	"github.com/Gong-Yang/g-micor/ginx"
+	_ "github.com/Gong-Yang/g-micor/ginx/openapi"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testModule struct {
+	started, stopped *bool
+}
+
+func (m testModule) Init(s grpc.ServiceRegistrar) string {
+	return "test"
+}
+
+func (m testModule) Router(router gin.IRouter) {
+	router.GET("/ping", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "pong")
+	})
+}
+
+func (m testModule) Config() any {
+	return nil
+}
+
+func (m testModule) OnStart(ctx context.Context) error {
+	*m.started = true
+	return nil
+}
+
+func (m testModule) OnStop(ctx context.Context) error {
+	*m.stopped = true
+	return nil
+}
+
+func TestStart(t *testing.T) {
+	var started, stopped bool
+	t.Run("启动模块", func(t *testing.T) {
+		env := Start(t, testModule{started: &started, stopped: &stopped})
+		if !started || len(env.Services) != 1 || env.Services[0] != "test" {
+			t.Fatalf("started = %v, services = %v", started, env.Services)
+		}
+		w := httptest.NewRecorder()
+		env.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		if w.Body.String() != "pong" {
+			t.Errorf("GET /ping = %q", w.Body.String())
+		}
+		if _, err := discover.NewClientClient(env.Conn).Ping(t.Context(), &discover.PingReq{}); err != nil {
+			t.Errorf("Ping() error = %v", err)
+		}
+	})
+	if !stopped {
+		t.Error("OnStop not called after test")
+	}
+}
+
+// checkModule 实现 HealthChecker 的模块
+type checkModule struct {
+	err error
+}
+
+func (m checkModule) Init(s grpc.ServiceRegistrar) string { return "" }
+func (m checkModule) Router(router gin.IRouter)           {}
+func (m checkModule) Config() any                         { return nil }
+
+func (m checkModule) HealthCheck(ctx context.Context) error {
+	return m.err
+}
+
+func TestHealth(t *testing.T) {
+	get := func(env *Env, path string) int {
+		w := httptest.NewRecorder()
+		env.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+	t.Run("检查通过", func(t *testing.T) {
+		env := Start(t, checkModule{})
+		for _, path := range []string{app.PathLive, app.PathHealth, app.PathReady} {
+			if code := get(env, path); code != http.StatusOK {
+				t.Errorf("GET %s = %d", path, code)
+			}
+		}
+	})
+	t.Run("模块检查失败", func(t *testing.T) {
+		env := Start(t, checkModule{err: errors.New("down")})
+		if code := get(env, app.PathLive); code != http.StatusOK {
+			t.Errorf("GET %s = %d", app.PathLive, code)
+		}
+		if code := get(env, app.PathReady); code != http.StatusServiceUnavailable {
+			t.Errorf("GET %s = %d", app.PathReady, code)
+		}
+		_, err := discover.NewClientClient(env.Conn).Ping(t.Context(), &discover.PingReq{})
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("Ping() error = %v", err)
+		}
+	})
+}
+
+// docModule 通过 ginx 注册路由的模块
+type docModule struct{}
+
+func (docModule) Init(s grpc.ServiceRegistrar) string { return "" }
+func (docModule) Config() any                         { return nil }
+
+func (docModule) Router(router gin.IRouter) {
+	ginx.GET(router, nil, "/doc/hello", func(ctx context.Context) (string, error) {
+		return "hello", nil
+	})
+}
+
+func TestNewRouterTwice(t *testing.T) {
+	old := app.Conf
+	app.Conf = &app.Config{App: app.AppConfig{Docs: "/docs"}}
+	t.Cleanup(func() { app.Conf = old })
+
+	first := app.NewRouter([]app.Module{docModule{}})
+	second := app.NewRouter([]app.Module{docModule{}})
+	for i, engine := range []*gin.Engine{first, second} {
+		if routes := ginx.Routes(engine); len(routes) != 1 {
+			t.Errorf("engine %d routes = %+v", i, routes)
+		}
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+		var doc struct {
+			Paths map[string]map[string]json.RawMessage `json:"paths"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil || len(doc.Paths) != 1 || len(doc.Paths["/doc/hello"]) != 1 {
+			t.Errorf("engine %d paths = %v, err = %v", i, doc.Paths, err)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package app
 
+import "github.com/Gong-Yang/g-micor/security"
+
 var Conf *Config
 
 type Config struct {
@@ -8,15 +10,41 @@ type Config struct {
 	PGSQL   PGSQLConfig `yaml:"pgSQL"`
 	Redis   RedisConfig
 	Observe OpenObserveConfig
+	Otel    OtelConfig
 }
 
 type AppConfig struct {
 	Name       string
 	Port       int
 	RpcPort    int    `yaml:"rpcPort"`
-	CenterAddr string `yaml:"centerAddr"`
-	LogLevel   string
-	HmacKey    string `yaml:"hmacKey"`
+	CenterAddr string `yaml:"centerAddr"` // 注册中心地址，多个用逗号分隔
+	Registry   string `yaml:"registry"`   // 注册中心类型 center(默认)/redis
+	// Registry 为 redis 时，Redis 未开启过期事件通知则通过 CONFIG SET 开启，共用的 Redis 上不要开启
+	RegistryKeyspaceEvents bool `yaml:"registryKeyspaceEvents"`
+	// 注册到注册中心的对外地址，容器/NAT环境下本机地址不可达时配置
+	// AdvertiseHost 支持环境变量，如 ${POD_IP}；AdvertisePort 默认为 RpcPort
+	// 自建注册中心只在 AdvertiseHost 与连接来源IP一致，或开启 mTLS 且证书包含该主机时采用，否则使用来源IP
+	AdvertiseHost string `yaml:"advertiseHost"`
+	AdvertisePort int    `yaml:"advertisePort"`
+	// RPC服务、注册中心及服务间调用的TLS证书，未配置时使用明文
+	TLS *security.TLSConfig `yaml:"tls"`
+	// 实例元数据，随注册上报，调用方可据此筛选实例
+	Version  string   // 版本，如 v1.2.0
+	Zone     string   // 所在区域/机房
+	Weight   int32    // 负载均衡权重，默认100
+	Tags     []string // 标签，如 canary
+	LogLevel string
+	HmacKey  string `yaml:"hmacKey"`
+	// 优雅停机超时时间（秒），默认15秒
+	ShutdownTimeout int `yaml:"shutdownTimeout"`
+	// 停机时就绪检查失败、注销后等待的时间（秒），等负载均衡和调用方摘除实例后再停止 HTTP/RPC 服务，计入停机超时
+	DrainDelay int `yaml:"drainDelay"`
+	// OpenAPI 文档和 Swagger UI 的路径，如 /docs，为空时不开启，开启时需导入 ginx/openapi 包
+	Docs string `yaml:"docs"`
+	// Prometheus 指标的路径，如 /metrics，为空时不开启
+	// MetricsPort 不为 0 时在单独的管理端口上暴露，路径默认为 /metrics，否则挂载在 web 端口上
+	Metrics     string `yaml:"metrics"`
+	MetricsPort int    `yaml:"metricsPort"`
 }
 
 type RedisConfig struct {
@@ -32,6 +60,14 @@ type MongoConfig struct {
 	Uri      string
 	Database string
 }
+
+// OtelConfig OpenTelemetry 导出配置
+type OtelConfig struct {
+	Endpoint    string  // OTLP gRPC 地址，如 otel-collector:4317，为空时不开启
+	Insecure    bool    // 不使用 TLS
+	SampleRatio float64 `yaml:"sampleRatio"` // 采样比例，默认 1
+}
+
 type OpenObserveConfig struct {
 	Endpoint     string
 	Organization string
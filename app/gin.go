@@ -1,30 +1,45 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
-	"sync"
+	"net/http"
 
 	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/Gong-Yang/g-micor/metricx"
 	"github.com/gin-gonic/gin"
 )
 
-func webStart(wg *sync.WaitGroup, service []Module) {
+func webStart(serveErr chan<- error, service []Module) *http.Server {
 	conf := Conf.App
 	addr := fmt.Sprintf(":%v", conf.Port)
+	gin.SetMode(gin.ReleaseMode)
+	srv := &http.Server{Addr: addr, Handler: NewRouter(service)}
 	// 监听
-	wg.Add(1)
 	go func() {
-		defer wg.Done()
-		gin.SetMode(gin.ReleaseMode)
-		engine := gin.New()
-		engine.Use(ginx.BasicMiddleware)
-		for _, server := range service {
-			server.Router(engine)
-		}
-		err := engine.Run(addr)
-		if err != nil {
+		err := srv.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			slog.Error("gin run error", "error", err)
+			serveErr <- err
 		}
 	}()
+	return srv
+}
+
+// NewRouter 创建挂载了各模块路由的 gin 引擎
+func NewRouter(modules []Module) *gin.Engine {
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	for _, module := range modules {
+		module.Router(engine)
+	}
+	mountHealth(engine, modules)
+	if Conf != nil && Conf.App.Docs != "" {
+		ginx.MountDocs(engine, Conf.App.Docs, ginx.OpenAPIInfo{Title: Conf.App.Name, Version: Conf.App.Version})
+	}
+	if Conf != nil && Conf.App.Metrics != "" && Conf.App.MetricsPort == 0 {
+		engine.GET(Conf.App.Metrics, gin.WrapH(metricx.Handler()))
+	}
+	return engine
 }
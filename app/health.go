@@ -0,0 +1,143 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthChecker 可选的模块健康检查，/healthz、/readyz 和注册中心的 Ping 会调用，返回错误表示模块不可用
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// 健康检查的路径
+const (
+	PathLive   = "/livez"   // 存活，进程能处理请求即成功
+	PathHealth = "/healthz" // 依赖和模块检查
+	PathReady  = "/readyz"  // 依赖和模块检查，停机开始后失败
+)
+
+// healthCheckTimeout 一次检查的超时时间
+const healthCheckTimeout = 3 * time.Second
+
+// ErrDraining 正在停机，不再接收新请求
+var ErrDraining = errors.New("draining")
+
+var (
+	// draining 停机开始后置为 true，就绪检查失败
+	draining atomic.Bool
+	// dependencies 已初始化的依赖的检查，Run 在初始化依赖后加入
+	dependencies   []dependency
+	dependencyLock sync.RWMutex
+)
+
+type dependency struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// addDependency 加入依赖的健康检查
+func addDependency(name string, check func(ctx context.Context) error) {
+	dependencyLock.Lock()
+	defer dependencyLock.Unlock()
+	dependencies = append(dependencies, dependency{name: name, check: check})
+}
+
+// healthChecks 依赖和模块的检查
+func healthChecks(modules []Module) []dependency {
+	dependencyLock.RLock()
+	checks := append([]dependency(nil), dependencies...)
+	dependencyLock.RUnlock()
+	for _, module := range modules {
+		if checker, ok := module.(HealthChecker); ok {
+			checks = append(checks, dependency{name: fmt.Sprintf("%T", module), check: checker.HealthCheck})
+		}
+	}
+	return checks
+}
+
+// checkHealth 并发执行检查，返回各项的结果，超时未完成的检查记为超时，ready 为 true 时停机开始后失败
+func checkHealth(ctx context.Context, modules []Module, ready bool) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	checks := healthChecks(modules)
+	type result struct {
+		i   int
+		err error
+	}
+	// 带缓冲，超时返回后未完成的检查也能写入并退出
+	done := make(chan result, len(checks))
+	for i, c := range checks {
+		go func() {
+			done <- result{i: i, err: c.check(ctx)}
+		}()
+	}
+	errs := make([]error, len(checks))
+	finished := make([]bool, len(checks))
+wait:
+	for range checks {
+		select {
+		case r := <-done:
+			errs[r.i] = r.err
+			finished[r.i] = true
+		case <-ctx.Done():
+			// 不响应 ctx 的检查不再等待，记为超时
+			break wait
+		}
+	}
+	for i := range checks {
+		if !finished[i] {
+			errs[i] = ctx.Err()
+		}
+	}
+	results := make(map[string]string, len(checks)+1)
+	var failed []error
+	for i, c := range checks {
+		results[c.name] = "ok"
+		if errs[i] != nil {
+			results[c.name] = errs[i].Error()
+			failed = append(failed, fmt.Errorf("%s: %w", c.name, errs[i]))
+		}
+	}
+	if ready && draining.Load() {
+		results["shutdown"] = ErrDraining.Error()
+		failed = append(failed, ErrDraining)
+	}
+	return results, errors.Join(failed...)
+}
+
+// readyCheck 就绪检查，用于注册中心的 Ping
+func readyCheck(modules []Module) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := checkHealth(ctx, modules, true)
+		return err
+	}
+}
+
+// healthHandler 返回检查结果，失败时状态码为 503
+func healthHandler(modules []Module, ready bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		results, err := checkHealth(ctx.Request.Context(), modules, ready)
+		if err != nil {
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{"status": "fail", "checks": results})
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"status": "ok", "checks": results})
+	}
+}
+
+// mountHealth 挂载存活、健康和就绪检查
+func mountHealth(router gin.IRouter, modules []Module) {
+	router.GET(PathLive, func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET(PathHealth, healthHandler(modules, false))
+	router.GET(PathReady, healthHandler(modules, true))
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 )
 
+var logHandler *logx.OpenObserveHandler
+
 func initLog() {
 	//初始化日志
 	// 创建OpenObserve日志选项，只配置必要参数
@@ -22,10 +24,18 @@ func initLog() {
 		Handler:       slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
 	}
 	// OpenObserve处理器
-	openObserveHandler := logx.NewOpenObserveHandler(opts, slog.LevelWarn)
+	logHandler = logx.NewOpenObserveHandler(opts, slog.LevelWarn)
 	// 创建logger
-	logger := slog.New(openObserveHandler)
+	logger := slog.New(logHandler)
 	// 设置为默认logger
 	slog.SetDefault(logger)
 	slog.Info("log init complete")
 }
+
+// closeLog 发送缓存中的日志并关闭处理器
+func closeLog() {
+	if logHandler == nil {
+		return
+	}
+	_ = logHandler.Close()
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/Gong-Yang/g-micor/metricx"
+)
+
+// metricsServer 单独的管理端口，未配置 metricsPort 时为空
+var metricsServer *http.Server
+
+// metricsStart 配置了 metricsPort 时在管理端口上暴露指标，停机过程中仍可采集，最后关闭
+func metricsStart(serveErr chan<- error) {
+	conf := Conf.App
+	if conf.MetricsPort == 0 {
+		return
+	}
+	path := conf.Metrics
+	if path == "" {
+		path = "/metrics"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, metricx.Handler())
+	metricsServer = &http.Server{Addr: fmt.Sprintf(":%v", conf.MetricsPort), Handler: mux}
+	go func() {
+		err := metricsServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics server run error", "error", err)
+			serveErr <- err
+		}
+	}()
+	slog.Info("metrics server start", "port", conf.MetricsPort, "path", path)
+}
+
+// closeMetrics 关闭管理端口
+func closeMetrics(ctx context.Context) {
+	if metricsServer == nil {
+		return
+	}
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		slog.Error("metrics server shutdown error", "error", err)
+	}
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Gong-Yang/g-micor/otelx"
+)
+
+var otelShutdown func(context.Context) error
+
+// initOtel 配置了 otel.endpoint 时通过 OTLP 导出链路和指标
+func initOtel(ctx context.Context) {
+	conf := Conf.Otel
+	if conf.Endpoint == "" {
+		return
+	}
+	shutdown, err := otelx.Setup(ctx, otelx.Config{
+		Endpoint:       conf.Endpoint,
+		Insecure:       conf.Insecure,
+		SampleRatio:    conf.SampleRatio,
+		ServiceName:    Conf.App.Name,
+		ServiceVersion: Conf.App.Version,
+	})
+	if err != nil {
+		panic(err)
+	}
+	otelShutdown = shutdown
+	slog.Info("otel init complete", "endpoint", conf.Endpoint)
+}
+
+// closeOtel 导出缓存中的链路和指标
+func closeOtel(ctx context.Context) {
+	if otelShutdown == nil {
+		return
+	}
+	if err := otelShutdown(ctx); err != nil {
+		slog.Error("otel shutdown error", "error", err)
+	}
+}
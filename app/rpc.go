@@ -1,47 +1,132 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
-	"sync"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/Gong-Yang/g-micor/discover"
+	"github.com/Gong-Yang/g-micor/redisx"
+	"github.com/Gong-Yang/g-micor/rpcx"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 )
 
-func rpcStart(wg *sync.WaitGroup, service []Module) {
+// healthServer 标准 grpc.health.v1 健康检查服务，注册中心据此判断实例状态
+var healthServer = health.NewServer()
+
+func rpcStart(serveErr chan<- error, service []Module) *grpc.Server {
 	conf := Conf.App
 	addr := fmt.Sprintf(":%v", conf.RpcPort)
-	gwAddr := conf.CenterAddr
 	// 监听
-	listener, _ := net.Listen("tcp", addr)
-	// 初始化服务
-	rpcApp := grpc.NewServer()
-	var ss []string
-	for _, s := range service {
-		serviceName := s.Init(rpcApp)
-		if serviceName == "" {
-			continue
-		}
-		slog.Info("register service", "service", serviceName)
-		ss = append(ss, serviceName)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		panic(err)
 	}
-	// 注册中心的客户端服务
-	discover.RegisterClientServer(rpcApp, discover.ClientService{})
-	wg.Add(1)
+	serverCreds, err := conf.TLS.ServerCredentials()
+	if err != nil {
+		panic(err)
+	}
+	clientCreds, err := conf.TLS.ClientCredentials()
+	if err != nil {
+		panic(err)
+	}
+	discover.SetClientCredentials(clientCreds)
+	// 初始化服务
+	rpcApp, ss := NewRPCServer(service, grpc.Creds(serverCreds))
 	go func() {
 		err := rpcApp.Serve(listener)
 		if err != nil {
 			slog.Error("rpc start error", "error", err)
+			serveErr <- err
 		}
-		wg.Done()
 	}()
 
 	//向注册中心发起注册
-	discover.RegisterCenter(gwAddr, &discover.RegisterReq{
-		Port:    addr,
+	registry, err := newRegistry(conf)
+	if err != nil {
+		panic(err)
+	}
+	discover.SetRegistry(registry)
+	err = discover.Register(context.Background(), &discover.RegisterReq{
+		Port:    advertisePort(conf),
+		Addr:    advertiseAddr(conf),
 		Servers: ss,
+		Metadata: &discover.Metadata{
+			Version:   conf.Version,
+			Zone:      conf.Zone,
+			Weight:    conf.Weight,
+			Tags:      conf.Tags,
+			StartTime: time.Now().UnixMilli(),
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	addDependency("registry", func(ctx context.Context) error {
+		return discover.Registered()
 	})
 	slog.Info("register success", "servers", ss)
+	return rpcApp
+}
+
+// NewRPCServer 创建 grpc 服务并注册各模块的服务，返回服务和模块名
+// 拦截器链与本地适配器共用，模块合并或拆分部署时行为一致
+func NewRPCServer(modules []Module, opts ...grpc.ServerOption) (*grpc.Server, []string) {
+	rpcApp := grpc.NewServer(append(opts, rpcx.DefaultChain.ServerOptions()...)...)
+	var ss []string
+	for _, module := range modules {
+		serviceName := module.Init(rpcApp)
+		if serviceName == "" {
+			continue
+		}
+		slog.Info("register service", "service", serviceName)
+		ss = append(ss, serviceName)
+	}
+	// 注册中心的客户端服务
+	discover.RegisterClientServer(rpcApp, discover.ClientService{Check: readyCheck(modules)})
+	healthgrpc.RegisterHealthServer(rpcApp, healthServer)
+	return rpcApp, ss
+}
+
+// advertiseAddr 配置的对外地址，未配置时返回空，由注册中心推断
+func advertiseAddr(conf AppConfig) string {
+	host := os.ExpandEnv(conf.AdvertiseHost)
+	if host == "" {
+		return ""
+	}
+	port := conf.AdvertisePort
+	if port == 0 {
+		port = conf.RpcPort
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// advertisePort 对外端口，未配置 AdvertiseHost 时注册中心用其与对端IP拼接地址
+func advertisePort(conf AppConfig) string {
+	if conf.AdvertisePort != 0 {
+		return fmt.Sprintf(":%v", conf.AdvertisePort)
+	}
+	return fmt.Sprintf(":%v", conf.RpcPort)
+}
+
+// newRegistry 根据配置创建注册中心后端
+func newRegistry(conf AppConfig) (discover.Registry, error) {
+	switch conf.Registry {
+	case "", discover.RegistryCenter:
+		return discover.NewCenterRegistry(conf.CenterAddr)
+	case discover.RegistryRedis:
+		var opts []discover.RedisOption
+		if conf.RegistryKeyspaceEvents {
+			opts = append(opts, discover.WithKeyspaceEvents())
+		}
+		return discover.NewRedisRegistry(redisx.Client, opts...)
+	default:
+		return nil, fmt.Errorf("unknown registry: %s", conf.Registry)
+	}
 }
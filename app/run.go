@@ -1,9 +1,12 @@
 package app
 
 import (
+	"context"
+	"log/slog"
 	"os"
+	"os/signal"
 	"reflect"
-	"sync"
+	"syscall"
 
 	"github.com/Gong-Yang/g-micor/config"
 	"github.com/Gong-Yang/g-micor/mongox"
@@ -20,35 +23,75 @@ type Module interface {
 	Config() any
 }
 
+// Starter 可选的模块启动钩子
+// 在配置、日志、数据库、Redis 初始化完成之后，开始监听端口之前调用
+type Starter interface {
+	OnStart(ctx context.Context) error
+}
+
+// Stopper 可选的模块停止钩子
+// 在停止接收请求、处理完存量请求之后，关闭数据库连接之前调用
+type Stopper interface {
+	OnStop(ctx context.Context) error
+}
+
 func Run(modules ...Module) {
-	var wg = &sync.WaitGroup{}
+	// 监听退出信号
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 	// 初始化配置
 	InitConf(modules)
 	var Hostname, _ = os.Hostname()
 	Hostname = Conf.App.Name + ":" + Hostname
 	// 初始化日志
 	initLog()
+	// 初始化链路和指标导出
+	initOtel(ctx)
 	// 初始化mongo
 	if Conf.Mongo.Uri != "" {
 		err := mongox.InitDB(Conf.Mongo.Uri, Conf.Mongo.Database)
 		if err != nil {
 			panic(err)
 		}
+		addDependency("mongo", mongox.Ping)
 	}
 	if Conf.PGSQL.Uri != "" {
 		err := pgsql.Init(Conf.PGSQL.Uri)
 		if err != nil {
 			panic(err)
 		}
+		addDependency("pgsql", pgsql.Ping)
 	}
 	// 初始化Redis
 	redisConf := Conf.Redis
 	redisx.Init(Hostname, &redis.Options{Addr: redisConf.Addr, Password: redisConf.Password, DB: redisConf.Db})
+	addDependency("redis", func(ctx context.Context) error {
+		return redisx.Client.Ping(ctx).Err()
+	})
+	// 模块启动钩子
+	for _, module := range modules {
+		if starter, ok := module.(Starter); ok {
+			if err := starter.OnStart(ctx); err != nil {
+				panic(err)
+			}
+		}
+	}
+	serveErr := make(chan error, 3)
+	// 初始化指标管理端口
+	metricsStart(serveErr)
 	// 初始化web
-	webStart(wg, modules)
+	webServer := webStart(serveErr, modules)
 	// 初始化RPC
-	rpcStart(wg, modules)
-	wg.Wait()
+	rpcServer := rpcStart(serveErr, modules)
+
+	select {
+	case <-ctx.Done():
+		slog.Info("receive stop signal, start graceful shutdown")
+	case err := <-serveErr:
+		slog.Error("server stopped unexpectedly, start graceful shutdown", "error", err)
+	}
+	stop()
+	shutdown(webServer, rpcServer, modules)
 }
 
 func InitConf(modules []Module) {
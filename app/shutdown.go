@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Gong-Yang/g-micor/discover"
+	"github.com/Gong-Yang/g-micor/mongox"
+	"github.com/Gong-Yang/g-micor/pgsql"
+	"github.com/Gong-Yang/g-micor/redisx"
+	"google.golang.org/grpc"
+)
+
+const defaultShutdownTimeout = 15 * time.Second
+
+// shutdown 优雅停机
+// 顺序：就绪检查失败、健康检查置为 NOT_SERVING -> 注销注册中心 -> 等待 drainDelay -> 停止 HTTP/RPC 并等待存量请求 -> 停止MQ监听 -> 模块停止钩子 -> 关闭连接池 -> 导出链路和指标 -> 关闭指标管理端口 -> 刷新日志
+func shutdown(webServer *http.Server, rpcServer *grpc.Server, modules []Module) {
+	timeout := defaultShutdownTimeout
+	if Conf.App.ShutdownTimeout > 0 {
+		timeout = time.Duration(Conf.App.ShutdownTimeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// 就绪检查失败，健康检查返回 NOT_SERVING，注销失败时注册中心也会将实例标记为 draining
+	draining.Store(true)
+	healthServer.Shutdown()
+	// 从注册中心下线，避免新流量进入
+	if err := discover.Deregister(ctx); err != nil {
+		slog.Error("deregister from center error", "error", err)
+	}
+	if err := discover.Close(); err != nil {
+		slog.Error("close center connection error", "error", err)
+	}
+	// 等待就绪探针和调用方感知下线，期间仍正常处理请求
+	if Conf.App.DrainDelay > 0 {
+		delay := time.Duration(Conf.App.DrainDelay) * time.Second
+		slog.Info("draining before stopping servers", "delay", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	// 停止 HTTP 服务，等待处理中的请求结束
+	if err := webServer.Shutdown(ctx); err != nil {
+		slog.Error("web server shutdown error", "error", err)
+	}
+
+	// 停止 RPC 服务，超时则强制关闭
+	stopped := make(chan struct{})
+	go func() {
+		rpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Warn("rpc server graceful stop timeout, force stop")
+		rpcServer.Stop()
+	}
+
+	// 停止MQ监听
+	redisx.StopMq(ctx)
+
+	// 模块停止钩子
+	for _, module := range modules {
+		if stopper, ok := module.(Stopper); ok {
+			if err := stopper.OnStop(ctx); err != nil {
+				slog.Error("module stop error", "error", err)
+			}
+		}
+	}
+
+	// 关闭连接池
+	if err := mongox.Close(ctx); err != nil {
+		slog.Error("mongo close error", "error", err)
+	}
+	pgsql.Close()
+	if err := redisx.Close(); err != nil {
+		slog.Error("redis close error", "error", err)
+	}
+
+	// 导出剩余的链路和指标
+	closeOtel(ctx)
+	closeMetrics(ctx)
+
+	slog.Info("graceful shutdown complete")
+	// 刷新日志
+	closeLog()
+}
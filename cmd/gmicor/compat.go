@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ErrIncompatible proto存在与快照不兼容的变更
+var ErrIncompatible = errors.New("proto is not backward compatible with snapshot")
+
+// snapshotRel 模块proto快照的相对路径，与proto同目录，需提交到版本库
+func (p *project) snapshotRel(pkg string) string {
+	return filepath.Join(p.contractRel(pkg), pkg+".snapshot.json")
+}
+
+// currentDescriptor 编译模块proto，返回去掉源码信息的文件描述
+func (p *project) currentDescriptor(pkg string) (*descriptorpb.FileDescriptorProto, error) {
+	set, err := p.compileProto(pkg)
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.ToSlash(p.protoRel(pkg))
+	for _, file := range set.File {
+		if file.GetName() == name {
+			file.SourceCodeInfo = nil
+			return file, nil
+		}
+	}
+	return nil, fmt.Errorf("proto file not found in descriptor set: %s", name)
+}
+
+// snapshot 将模块proto的当前描述写入快照
+func (p *project) snapshot(pkg string) error {
+	file, err := p.currentDescriptor(pkg)
+	if err != nil {
+		return err
+	}
+	data, err := protojson.Marshal(file)
+	if err != nil {
+		return err
+	}
+	// protojson 的输出格式不稳定，重新缩进便于比较和审阅
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, data, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	snapshotFile := filepath.Join(p.root, p.snapshotRel(pkg))
+	if err = os.WriteFile(snapshotFile, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	slog.Info("Proto snapshot written", "package", pkg, "file", snapshotFile)
+	return nil
+}
+
+// check 比较模块proto与快照，存在不兼容变更时输出并返回 ErrIncompatible
+func (p *project) check(pkg string) error {
+	data, err := os.ReadFile(filepath.Join(p.root, p.snapshotRel(pkg)))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("snapshot not found: %s, run `gmicor proto snapshot %s` first", p.snapshotRel(pkg), pkg)
+	}
+	if err != nil {
+		return err
+	}
+	old := &descriptorpb.FileDescriptorProto{}
+	if err = protojson.Unmarshal(data, old); err != nil {
+		return fmt.Errorf("invalid snapshot %s: %w", p.snapshotRel(pkg), err)
+	}
+	cur, err := p.currentDescriptor(pkg)
+	if err != nil {
+		return err
+	}
+	problems, warnings := checkCompat(old, cur)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "%s: warning: %s\n", p.protoRel(pkg), warning)
+	}
+	if len(problems) == 0 {
+		slog.Info("Proto is compatible with snapshot", "package", pkg, "warnings", len(warnings))
+		return nil
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", p.protoRel(pkg), problem)
+	}
+	return ErrIncompatible
+}
+
+// forEachModule 对 all 或指定模块执行 fn
+func (p *project) forEachModule(target string, fn func(pkg string) error) error {
+	if target != "all" {
+		return fn(target)
+	}
+	modules, err := p.modules()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, pkg := range modules {
+		if err = fn(pkg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", pkg, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkCompat 返回 cur 相对 old 在线上传输层面不兼容的变更：
+// 字段或枚举值的编号被复用或改变、字段类型改变、删除字段或枚举值未保留编号、删除服务或方法、方法出入参或流类型改变；
+// 以及只影响 JSON 编码的警告：同一编号的字段或枚举值改名
+func checkCompat(old, cur *descriptorpb.FileDescriptorProto) (problems, warnings []string) {
+	curMessages := messagesByName(cur)
+	for name, oldMsg := range messagesByName(old) {
+		curMsg, ok := curMessages[name]
+		if !ok {
+			continue
+		}
+		p, w := checkMessage(name, oldMsg, curMsg)
+		problems = append(problems, p...)
+		warnings = append(warnings, w...)
+	}
+	curEnums := enumsByName(cur)
+	for name, oldEnum := range enumsByName(old) {
+		curEnum, ok := curEnums[name]
+		if !ok {
+			continue
+		}
+		p, w := checkEnum(name, oldEnum, curEnum)
+		problems = append(problems, p...)
+		warnings = append(warnings, w...)
+	}
+
+	curServices := make(map[string]*descriptorpb.ServiceDescriptorProto)
+	for _, s := range cur.GetService() {
+		curServices[s.GetName()] = s
+	}
+	for _, oldService := range old.GetService() {
+		curService, ok := curServices[oldService.GetName()]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("service %s removed", oldService.GetName()))
+			continue
+		}
+		curMethods := make(map[string]*descriptorpb.MethodDescriptorProto)
+		for _, m := range curService.GetMethod() {
+			curMethods[m.GetName()] = m
+		}
+		for _, oldMethod := range oldService.GetMethod() {
+			name := oldService.GetName() + "." + oldMethod.GetName()
+			curMethod, ok := curMethods[oldMethod.GetName()]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("rpc %s removed", name))
+				continue
+			}
+			if oldMethod.GetInputType() != curMethod.GetInputType() {
+				problems = append(problems, fmt.Sprintf("rpc %s request type changed from %s to %s", name, oldMethod.GetInputType(), curMethod.GetInputType()))
+			}
+			if oldMethod.GetOutputType() != curMethod.GetOutputType() {
+				problems = append(problems, fmt.Sprintf("rpc %s response type changed from %s to %s", name, oldMethod.GetOutputType(), curMethod.GetOutputType()))
+			}
+			if streamKind(oldMethod) != streamKind(curMethod) {
+				problems = append(problems, fmt.Sprintf("rpc %s streaming changed from %s to %s", name, streamKind(oldMethod), streamKind(curMethod)))
+			}
+		}
+	}
+	sort.Strings(problems)
+	sort.Strings(warnings)
+	return problems, warnings
+}
+
+// checkMessage 比较同名消息的字段
+func checkMessage(name string, old, cur *descriptorpb.DescriptorProto) (problems, warnings []string) {
+	oldByName := make(map[string]*descriptorpb.FieldDescriptorProto)
+	for _, f := range old.GetField() {
+		oldByName[f.GetName()] = f
+	}
+	curByNumber := make(map[int32]*descriptorpb.FieldDescriptorProto)
+	curByName := make(map[string]*descriptorpb.FieldDescriptorProto)
+	for _, f := range cur.GetField() {
+		curByNumber[f.GetNumber()] = f
+		curByName[f.GetName()] = f
+	}
+	for _, oldField := range old.GetField() {
+		number := oldField.GetNumber()
+		curField, ok := curByNumber[number]
+		// 新名字原本属于其他编号的字段，或类型不同，视为复用编号
+		_, reused := oldByName[curField.GetName()]
+		switch {
+		case ok && curField.GetName() != oldField.GetName() && (reused || fieldType(oldField) != fieldType(curField)):
+			problems = append(problems, fmt.Sprintf("message %s field number %d reused: %s -> %s", name, number, oldField.GetName(), curField.GetName()))
+		case ok && curField.GetName() != oldField.GetName():
+			warnings = append(warnings, fmt.Sprintf("message %s field %d renamed: %s -> %s, JSON clients are affected", name, number, oldField.GetName(), curField.GetName()))
+		case ok && fieldType(oldField) != fieldType(curField):
+			problems = append(problems, fmt.Sprintf("message %s field %s type changed from %s to %s", name, oldField.GetName(), fieldType(oldField), fieldType(curField)))
+		case !ok && !reserved(cur, number):
+			if moved, ok := curByName[oldField.GetName()]; ok {
+				problems = append(problems, fmt.Sprintf("message %s field %s number changed from %d to %d", name, oldField.GetName(), number, moved.GetNumber()))
+			} else {
+				problems = append(problems, fmt.Sprintf("message %s field %s (%d) removed without reserving its number", name, oldField.GetName(), number))
+			}
+		}
+	}
+	return problems, warnings
+}
+
+// checkEnum 比较同名枚举的值
+func checkEnum(name string, old, cur *descriptorpb.EnumDescriptorProto) (problems, warnings []string) {
+	oldByName := make(map[string]*descriptorpb.EnumValueDescriptorProto)
+	for _, v := range old.GetValue() {
+		oldByName[v.GetName()] = v
+	}
+	curByNumber := make(map[int32]*descriptorpb.EnumValueDescriptorProto)
+	curByName := make(map[string]*descriptorpb.EnumValueDescriptorProto)
+	for _, v := range cur.GetValue() {
+		// 开启 allow_alias 时同一编号有多个名字，以第一个为准
+		if _, ok := curByNumber[v.GetNumber()]; !ok {
+			curByNumber[v.GetNumber()] = v
+		}
+		curByName[v.GetName()] = v
+	}
+	for _, oldValue := range old.GetValue() {
+		number := oldValue.GetNumber()
+		if v, ok := curByName[oldValue.GetName()]; ok && v.GetNumber() == number {
+			continue
+		}
+		// 新名字原本属于其他编号的值，视为复用编号
+		curValue, ok := curByNumber[number]
+		_, reused := oldByName[curValue.GetName()]
+		switch {
+		case ok && reused:
+			problems = append(problems, fmt.Sprintf("enum %s value number %d reused: %s -> %s", name, number, oldValue.GetName(), curValue.GetName()))
+		case ok:
+			warnings = append(warnings, fmt.Sprintf("enum %s value %d renamed: %s -> %s, JSON clients are affected", name, number, oldValue.GetName(), curValue.GetName()))
+		case !enumReserved(cur, number):
+			if movedTo, ok := curByName[oldValue.GetName()]; ok {
+				problems = append(problems, fmt.Sprintf("enum %s value %s number changed from %d to %d", name, oldValue.GetName(), number, movedTo.GetNumber()))
+			} else {
+				problems = append(problems, fmt.Sprintf("enum %s value %s (%d) removed without reserving its number", name, oldValue.GetName(), number))
+			}
+		}
+	}
+	return problems, warnings
+}
+
+// messagesByName 文件中的所有消息，包括嵌套消息，key 为相对包的全名
+func messagesByName(file *descriptorpb.FileDescriptorProto) map[string]*descriptorpb.DescriptorProto {
+	res := make(map[string]*descriptorpb.DescriptorProto)
+	var walk func(prefix string, messages []*descriptorpb.DescriptorProto)
+	walk = func(prefix string, messages []*descriptorpb.DescriptorProto) {
+		for _, m := range messages {
+			name := prefix + m.GetName()
+			res[name] = m
+			walk(name+".", m.GetNestedType())
+		}
+	}
+	walk("", file.GetMessageType())
+	return res
+}
+
+// enumsByName 文件中的所有枚举，包括消息内的枚举，key 为相对包的全名
+func enumsByName(file *descriptorpb.FileDescriptorProto) map[string]*descriptorpb.EnumDescriptorProto {
+	res := make(map[string]*descriptorpb.EnumDescriptorProto)
+	for _, e := range file.GetEnumType() {
+		res[e.GetName()] = e
+	}
+	for name, m := range messagesByName(file) {
+		for _, e := range m.GetEnumType() {
+			res[name+"."+e.GetName()] = e
+		}
+	}
+	return res
+}
+
+// fieldType 字段的线上类型，包括 repeated 和消息、枚举的类型名
+func fieldType(f *descriptorpb.FieldDescriptorProto) string {
+	t := strings.ToLower(strings.TrimPrefix(f.GetType().String(), "TYPE_"))
+	if f.GetTypeName() != "" {
+		t += " " + f.GetTypeName()
+	}
+	if f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		t = "repeated " + t
+	}
+	return t
+}
+
+// reserved 字段编号是否已保留
+func reserved(m *descriptorpb.DescriptorProto, number int32) bool {
+	for _, r := range m.GetReservedRange() {
+		// ReservedRange 的 end 不包含在内
+		if number >= r.GetStart() && number < r.GetEnd() {
+			return true
+		}
+	}
+	return false
+}
+
+// enumReserved 枚举值编号是否已保留
+func enumReserved(e *descriptorpb.EnumDescriptorProto, number int32) bool {
+	for _, r := range e.GetReservedRange() {
+		// EnumReservedRange 的 end 包含在内
+		if number >= r.GetStart() && number <= r.GetEnd() {
+			return true
+		}
+	}
+	return false
+}
+
+// streamKind 方法的流类型
+func streamKind(m *descriptorpb.MethodDescriptorProto) string {
+	switch {
+	case m.GetClientStreaming() && m.GetServerStreaming():
+		return "bidi-streaming"
+	case m.GetClientStreaming():
+		return "client-streaming"
+	case m.GetServerStreaming():
+		return "server-streaming"
+	}
+	return "unary"
+}
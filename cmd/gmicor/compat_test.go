@@ -0,0 +1,148 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func field(name string, number int32, t descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Type:   t.Enum(),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+}
+
+func method(name string, clientStreaming, serverStreaming bool) *descriptorpb.MethodDescriptorProto {
+	return &descriptorpb.MethodDescriptorProto{
+		Name:            proto.String(name),
+		InputType:       proto.String(".userC.HelloReq"),
+		OutputType:      proto.String(".userC.HelloRes"),
+		ClientStreaming: proto.Bool(clientStreaming),
+		ServerStreaming: proto.Bool(serverStreaming),
+	}
+}
+
+func enumValue(name string, number int32) *descriptorpb.EnumValueDescriptorProto {
+	return &descriptorpb.EnumValueDescriptorProto{Name: proto.String(name), Number: proto.Int32(number)}
+}
+
+// baseFile 快照中的proto
+func baseFile() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("contract/userC/user.proto"),
+		Package: proto.String("userC"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("HelloReq"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("age", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+			},
+		}, {
+			Name: proto.String("HelloRes"),
+		}},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				enumValue("UNKNOWN", 0),
+				enumValue("ACTIVE", 1),
+				enumValue("BANNED", 2),
+			},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:   proto.String("User"),
+			Method: []*descriptorpb.MethodDescriptorProto{method("Hello", false, false), method("Watch", false, true)},
+		}},
+	}
+}
+
+func TestCheckCompat(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(f *descriptorpb.FileDescriptorProto)
+		want     []string
+		warnings []string
+	}{
+		{"新增字段和方法", func(f *descriptorpb.FileDescriptorProto) {
+			msg := f.MessageType[0]
+			msg.Field = append(msg.Field, field("email", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING))
+			f.Service[0].Method = append(f.Service[0].Method, method("Bye", false, false))
+		}, nil, nil},
+		{"删除字段并保留编号", func(f *descriptorpb.FileDescriptorProto) {
+			msg := f.MessageType[0]
+			msg.Field = msg.Field[:1]
+			msg.ReservedRange = []*descriptorpb.DescriptorProto_ReservedRange{{Start: proto.Int32(2), End: proto.Int32(3)}}
+		}, nil, nil},
+		{"删除字段未保留编号", func(f *descriptorpb.FileDescriptorProto) {
+			f.MessageType[0].Field = f.MessageType[0].Field[:1]
+		}, []string{"message HelloReq field age (2) removed without reserving its number"}, nil},
+		{"复用字段编号", func(f *descriptorpb.FileDescriptorProto) {
+			f.MessageType[0].Field[1] = field("email", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+		}, []string{"message HelloReq field number 2 reused: age -> email"}, nil},
+		{"字段改名", func(f *descriptorpb.FileDescriptorProto) {
+			f.MessageType[0].Field[1].Name = proto.String("years")
+		}, nil, []string{"message HelloReq field 2 renamed: age -> years, JSON clients are affected"}},
+		{"交换字段编号", func(f *descriptorpb.FileDescriptorProto) {
+			f.MessageType[0].Field[0].Number, f.MessageType[0].Field[1].Number = proto.Int32(2), proto.Int32(1)
+		}, []string{
+			"message HelloReq field number 1 reused: name -> age",
+			"message HelloReq field number 2 reused: age -> name",
+		}, nil},
+		{"新增枚举值和删除枚举值并保留编号", func(f *descriptorpb.FileDescriptorProto) {
+			e := f.EnumType[0]
+			e.Value = append(e.Value[:2], enumValue("DELETED", 3))
+			e.ReservedRange = []*descriptorpb.EnumDescriptorProto_EnumReservedRange{{Start: proto.Int32(2), End: proto.Int32(2)}}
+		}, nil, nil},
+		{"删除枚举值未保留编号", func(f *descriptorpb.FileDescriptorProto) {
+			f.EnumType[0].Value = f.EnumType[0].Value[:2]
+		}, []string{"enum Status value BANNED (2) removed without reserving its number"}, nil},
+		{"复用枚举值编号", func(f *descriptorpb.FileDescriptorProto) {
+			f.EnumType[0].Value = []*descriptorpb.EnumValueDescriptorProto{enumValue("UNKNOWN", 0), enumValue("BANNED", 1)}
+		}, []string{
+			"enum Status value BANNED number changed from 2 to 1",
+			"enum Status value number 1 reused: ACTIVE -> BANNED",
+		}, nil},
+		{"枚举值改名", func(f *descriptorpb.FileDescriptorProto) {
+			f.EnumType[0].Value[2].Name = proto.String("BLOCKED")
+		}, nil, []string{"enum Status value 2 renamed: BANNED -> BLOCKED, JSON clients are affected"}},
+		{"改变字段编号", func(f *descriptorpb.FileDescriptorProto) {
+			f.MessageType[0].Field[1].Number = proto.Int32(5)
+		}, []string{"message HelloReq field age number changed from 2 to 5"}, nil},
+		{"改变字段类型", func(f *descriptorpb.FileDescriptorProto) {
+			f.MessageType[0].Field[1].Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+		}, []string{"message HelloReq field age type changed from int32 to int64"}, nil},
+		{"改为repeated", func(f *descriptorpb.FileDescriptorProto) {
+			f.MessageType[0].Field[0].Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		}, []string{"message HelloReq field name type changed from string to repeated string"}, nil},
+		{"删除方法", func(f *descriptorpb.FileDescriptorProto) {
+			f.Service[0].Method = f.Service[0].Method[1:]
+		}, []string{"rpc User.Hello removed"}, nil},
+		{"删除服务", func(f *descriptorpb.FileDescriptorProto) {
+			f.Service = nil
+		}, []string{"service User removed"}, nil},
+		{"改变流类型和出参", func(f *descriptorpb.FileDescriptorProto) {
+			f.Service[0].Method[1].ClientStreaming = proto.Bool(true)
+			f.Service[0].Method[0].OutputType = proto.String(".userC.HelloReq")
+		}, []string{
+			"rpc User.Hello response type changed from .userC.HelloRes to .userC.HelloReq",
+			"rpc User.Watch streaming changed from server-streaming to bidi-streaming",
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := baseFile()
+			tt.change(cur)
+			problems, warnings := checkCompat(baseFile(), cur)
+			if !reflect.DeepEqual(problems, tt.want) {
+				t.Errorf("checkCompat() problems = %q, want %q", problems, tt.want)
+			}
+			if !reflect.DeepEqual(warnings, tt.warnings) {
+				t.Errorf("checkCompat() warnings = %q, want %q", warnings, tt.warnings)
+			}
+		})
+	}
+}
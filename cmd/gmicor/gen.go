@@ -0,0 +1,311 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Gong-Yang/g-micor/internal/generator"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// gen 根据模块的proto文件生成代码
+func (p *project) gen(pkg string) error {
+	slog.Info("Starting auto code generation", "package", pkg)
+
+	// 1. 执行protoc命令生成go文件，同时输出描述符
+	set, err := p.generateProtoCode(pkg)
+	if err != nil {
+		return fmt.Errorf("failed to generate proto code: %w", err)
+	}
+
+	// 2. 由描述符构建与protoc插件一致的生成环境
+	protoFile := filepath.ToSlash(p.protoRel(pkg))
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{protoFile},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile:      set.File,
+	})
+	if err != nil {
+		return err
+	}
+	file := plugin.FilesByPath[protoFile]
+	if file == nil {
+		return fmt.Errorf("proto file not found in descriptor set: %s", protoFile)
+	}
+	if len(file.Services) == 0 {
+		slog.Info("No service found in proto file")
+		return nil
+	}
+	slog.Info("Found proto services", "services", len(file.Services))
+
+	conf := generator.Config{
+		EndpointDir:    filepath.ToSlash(filepath.Join(p.moduleDir, pkg, "endpoint")),
+		EndpointImport: protogen.GoImportPath(p.endpointImport(pkg)),
+	}
+
+	// 3. 补充服务端实现中缺失的方法
+	existing, err := scanEndpoint(p.endpointAbs(pkg))
+	if err != nil {
+		return fmt.Errorf("failed to scan endpoint: %w", err)
+	}
+	if stubs := generator.Stubs(plugin, file, conf, existing); stubs != nil {
+		content, err := stubs.Content()
+		if err != nil {
+			return err
+		}
+		if err = mergeStubs(filepath.Join(p.endpointAbs(pkg), generator.StubFilename), content); err != nil {
+			return fmt.Errorf("failed to ensure service methods: %w", err)
+		}
+	}
+
+	// 4. 生成contract_gen.go和localAdapter_gen.go文件
+	generator.Generate(plugin, file, conf)
+	resp := plugin.Response()
+	if resp.Error != nil {
+		return fmt.Errorf("generate: %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		filePath := filepath.Join(p.root, filepath.FromSlash(f.GetName()))
+		if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return err
+		}
+		if err = os.WriteFile(filePath, []byte(f.GetContent()), 0644); err != nil {
+			return err
+		}
+		slog.Info("Generated file", "path", filePath)
+	}
+
+	slog.Info("Code generation completed successfully", "package", pkg)
+	return nil
+}
+
+// genAll 为所有模块生成代码
+func (p *project) genAll() error {
+	modules, err := p.modules()
+	if err != nil {
+		return err
+	}
+	if len(modules) == 0 {
+		slog.Info("No module found", "dir", p.contractDir)
+		return nil
+	}
+	for _, pkg := range modules {
+		if err = p.gen(pkg); err != nil {
+			return fmt.Errorf("%s: %w", pkg, err)
+		}
+	}
+	return nil
+}
+
+// 执行protoc命令生成go文件，并返回包含依赖的描述符集合
+func (p *project) generateProtoCode(pkg string) (*descriptorpb.FileDescriptorSet, error) {
+	set, err := p.compileProto(pkg,
+		"--go_out=.",
+		"--go_opt=paths=source_relative",
+		"--go-grpc_out=.",
+		"--go-grpc_opt=paths=source_relative")
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Proto code generated successfully", "package", pkg)
+	return set, nil
+}
+
+// compileProto 执行protoc编译模块的proto文件，args 为额外的输出参数，返回包含依赖的描述符集合
+func (p *project) compileProto(pkg string, args ...string) (*descriptorpb.FileDescriptorSet, error) {
+	protoFile := p.protoRel(pkg)
+
+	// 检查proto文件是否存在
+	if _, err := os.Stat(filepath.Join(p.root, protoFile)); os.IsNotExist(err) {
+		return nil, fmt.Errorf("proto file not found: %s", protoFile)
+	}
+
+	descFile, err := os.CreateTemp("", "gmicor-*.pb")
+	if err != nil {
+		return nil, err
+	}
+	descFile.Close()
+	defer os.Remove(descFile.Name())
+
+	args = append(args,
+		"--descriptor_set_out="+descFile.Name(),
+		"--include_imports",
+		"--include_source_info",
+		protoFile)
+	cmd := exec.Command(*protoc, args...)
+	cmd.Dir = p.root
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("protoc command failed: %v, output: %s", err, string(output))
+	}
+
+	data, err := os.ReadFile(descFile.Name())
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(data, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// scanEndpoint 收集 endpoint 包中手写代码已声明的类型和方法
+func scanEndpoint(dir string) (generator.Existing, error) {
+	existing := generator.Existing{
+		Types:   make(map[string]bool),
+		Methods: make(map[string]map[string]bool),
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return existing, err
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_gen.go") || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		node, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return existing, err
+		}
+		for _, decl := range node.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						existing.Types[ts.Name.Name] = true
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv == nil || len(d.Recv.List) == 0 {
+					continue
+				}
+				recv := getReceiverType(d.Recv.List[0])
+				if existing.Methods[recv] == nil {
+					existing.Methods[recv] = make(map[string]bool)
+				}
+				existing.Methods[recv][d.Name.Name] = true
+			}
+		}
+	}
+	return existing, nil
+}
+
+// 获取接收者类型名
+func getReceiverType(recv *ast.Field) string {
+	t := recv.Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if ident, ok := t.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// mergeStubs 将缺失的类型和方法写入 rpcServer.go，文件已存在时追加到末尾并补充导入
+func mergeStubs(filePath string, stubs []byte) error {
+	content, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return err
+		}
+		slog.Info("Created base service file", "file", filePath)
+		return os.WriteFile(filePath, stubs, 0644)
+	}
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	stubFile, err := parser.ParseFile(fset, "", stubs, 0)
+	if err != nil {
+		return err
+	}
+	existFile, err := parser.ParseFile(fset, filePath, content, parser.ImportsOnly)
+	if err != nil {
+		return err
+	}
+
+	// 新增的声明
+	var body []byte
+	for _, decl := range stubFile.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			continue
+		}
+		body = stubs[fset.Position(decl.Pos()).Offset:]
+		break
+	}
+
+	// 缺失的导入
+	imported := make(map[string]bool)
+	for _, spec := range existFile.Imports {
+		imported[spec.Path.Value] = true
+	}
+	var missing []string
+	for _, spec := range stubFile.Imports {
+		if imported[spec.Path.Value] {
+			continue
+		}
+		line := spec.Path.Value
+		if spec.Name != nil {
+			line = spec.Name.Name + " " + line
+		}
+		missing = append(missing, line)
+	}
+	if len(missing) > 0 {
+		content = addImports(fset, existFile, content, missing)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(content)
+	buf.WriteString("\n")
+	buf.Write(body)
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	slog.Info("Added missing methods", "file", filePath)
+	return os.WriteFile(filePath, formatted, 0644)
+}
+
+// addImports 在已有文件中加入导入，优先并入已有的 import 声明
+func addImports(fset *token.FileSet, file *ast.File, content []byte, imports []string) []byte {
+	lines := "\t" + strings.Join(imports, "\n\t") + "\n"
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		if gd.Lparen.IsValid() {
+			return splice(content, fset.Position(gd.Rparen).Offset, fset.Position(gd.Rparen).Offset, lines)
+		}
+		// 单行 import 改写为 import 块
+		spec := content[fset.Position(gd.Specs[0].Pos()).Offset:fset.Position(gd.End()).Offset]
+		return splice(content, fset.Position(gd.Pos()).Offset, fset.Position(gd.End()).Offset, "import (\n\t"+string(spec)+"\n"+lines+")")
+	}
+	offset := fset.Position(file.Name.End()).Offset
+	return splice(content, offset, offset, "\n\nimport (\n"+lines+")")
+}
+
+// splice 将 content[start:end] 替换为 s
+func splice(content []byte, start, end int, s string) []byte {
+	out := make([]byte, 0, len(content)+len(s))
+	out = append(out, content[:start]...)
+	out = append(out, s...)
+	return append(out, content[end:]...)
+}
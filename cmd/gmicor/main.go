@@ -0,0 +1,70 @@
+// gmicor 根据proto文件生成模块代码的命令行工具
+//
+//	gmicor gen <module>         根据 contract/<module>C/<module>.proto 生成代码
+//	gmicor gen all              为 contract 目录下的所有模块生成代码
+//	gmicor new module <name>    创建模块骨架(proto、endpoint、配置、app.Module 实现)并生成代码
+//	gmicor proto snapshot <module>|all  将proto的当前描述写入快照 contract/<module>C/<module>.snapshot.json
+//	gmicor proto check <module>|all     与快照比较，存在不兼容的变更时以非0状态退出
+//
+// 需在项目目录(或其子目录)下执行，模块路径从 go.mod 读取
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+var (
+	contractDir = flag.String("contract", "contract", "proto及生成的客户端代码所在目录")
+	moduleDir   = flag.String("module", "module", "模块实现所在目录")
+	protoc      = flag.String("protoc", "protoc", "protoc 可执行文件")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `用法:
+  gmicor [flags] gen <module>
+  gmicor [flags] gen all
+  gmicor [flags] new module <name>
+  gmicor [flags] proto snapshot <module>|all
+  gmicor [flags] proto check <module>|all
+
+flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	p, err := loadProject()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch {
+	case args[0] == "gen" && args[1] == "all":
+		err = p.genAll()
+	case args[0] == "gen":
+		err = p.gen(args[1])
+	case args[0] == "new" && args[1] == "module" && len(args) == 3:
+		err = p.newModule(args[2])
+	case args[0] == "proto" && args[1] == "snapshot" && len(args) == 3:
+		err = p.forEachModule(args[2], p.snapshot)
+	case args[0] == "proto" && args[1] == "check" && len(args) == 3:
+		err = p.forEachModule(args[2], p.check)
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
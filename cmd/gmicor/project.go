@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// project 当前项目的目录结构
+type project struct {
+	root        string // 项目根目录，即 go.mod 所在目录
+	modulePath  string // go.mod 中声明的模块路径
+	contractDir string // 相对 root 的 contract 目录
+	moduleDir   string // 相对 root 的 module 目录
+}
+
+// loadProject 从当前目录向上查找 go.mod
+func loadProject() (*project, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		modFile := filepath.Join(dir, "go.mod")
+		if _, err := os.Stat(modFile); err == nil {
+			modulePath, err := readModulePath(modFile)
+			if err != nil {
+				return nil, err
+			}
+			return &project{
+				root:        dir,
+				modulePath:  modulePath,
+				contractDir: *contractDir,
+				moduleDir:   *moduleDir,
+			}, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, errors.New("go.mod not found")
+		}
+		dir = parent
+	}
+}
+
+// readModulePath 读取 go.mod 中的 module 声明
+func readModulePath(modFile string) (string, error) {
+	file, err := os.Open(modFile)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if modulePath, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(modulePath), `"`), nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("module declaration not found in %s", modFile)
+}
+
+// contractPkg 模块的 contract 包名，如 user -> userC
+func contractPkg(pkg string) string {
+	return pkg + "C"
+}
+
+// contractRel 模块 contract 目录，相对项目根目录
+func (p *project) contractRel(pkg string) string {
+	return filepath.Join(p.contractDir, contractPkg(pkg))
+}
+
+// protoRel 模块proto文件，相对项目根目录
+func (p *project) protoRel(pkg string) string {
+	return filepath.Join(p.contractRel(pkg), pkg+".proto")
+}
+
+// moduleAbs 模块实现目录
+func (p *project) moduleAbs(pkg string) string {
+	return filepath.Join(p.root, p.moduleDir, pkg)
+}
+
+// endpointAbs 模块 endpoint 目录
+func (p *project) endpointAbs(pkg string) string {
+	return filepath.Join(p.moduleAbs(pkg), "endpoint")
+}
+
+// contractImport 模块 contract 包的导入路径
+func (p *project) contractImport(pkg string) string {
+	return path.Join(p.modulePath, filepath.ToSlash(p.contractRel(pkg)))
+}
+
+// endpointImport 模块 endpoint 包的导入路径
+func (p *project) endpointImport(pkg string) string {
+	return path.Join(p.modulePath, filepath.ToSlash(p.moduleDir), pkg, "endpoint")
+}
+
+// modules 扫描 contract 目录，返回所有包含同名proto文件的模块
+func (p *project) modules() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(p.root, p.contractDir))
+	if err != nil {
+		return nil, err
+	}
+	var modules []string
+	for _, entry := range entries {
+		pkg, ok := strings.CutSuffix(entry.Name(), "C")
+		if !entry.IsDir() || !ok {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(p.root, p.protoRel(pkg))); err == nil {
+			modules = append(modules, pkg)
+		}
+	}
+	return modules, nil
+}
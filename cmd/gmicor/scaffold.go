@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+var moduleNameRegex = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+const protoTemplate = `syntax = "proto3";
+option go_package = "{{.ContractImport}}";
+
+package {{.Package}}C;
+
+message HelloReq {
+  string name = 1;
+}
+
+message HelloRes {
+  string message = 1;
+}
+
+service {{.ServiceName}} {
+  rpc Hello(HelloReq) returns (HelloRes) {}
+}
+`
+
+const configTemplate = `package {{.Package}}
+
+// Config 模块配置，字段对应配置文件中的顶层key
+type Config struct {
+}
+
+var conf = &Config{}
+`
+
+const moduleTemplate = `package {{.Package}}
+
+import (
+	"{{.ContractImport}}"
+	"{{.EndpointImport}}"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+// Module {{.Package}} 模块，实现 app.Module
+type Module struct{}
+
+func (Module) Init(s grpc.ServiceRegistrar) string {
+	endpoint.InitRPC(s)
+	return {{.Package}}C.ModuleName
+}
+
+func (Module) Router(router gin.IRouter) {
+	endpoint.Router(router)
+}
+
+func (Module) Config() any {
+	return conf
+}
+`
+
+// newModule 创建模块骨架并生成代码
+func (p *project) newModule(pkg string) error {
+	if !moduleNameRegex.MatchString(pkg) {
+		return fmt.Errorf("invalid module name %q: must be lowercase letters and digits", pkg)
+	}
+	protoFile := filepath.Join(p.root, p.protoRel(pkg))
+	if _, err := os.Stat(protoFile); err == nil {
+		return fmt.Errorf("module %s already exists: %s", pkg, protoFile)
+	}
+	if _, err := os.Stat(p.moduleAbs(pkg)); err == nil {
+		return fmt.Errorf("module %s already exists: %s", pkg, p.moduleAbs(pkg))
+	}
+
+	data := struct {
+		Package        string
+		ServiceName    string
+		ContractImport string
+		EndpointImport string
+	}{
+		Package:        pkg,
+		ServiceName:    strings.ToUpper(pkg[:1]) + pkg[1:],
+		ContractImport: p.contractImport(pkg),
+		EndpointImport: p.endpointImport(pkg),
+	}
+	files := []struct {
+		path   string
+		tmpl   string
+		goCode bool
+	}{
+		{protoFile, protoTemplate, false},
+		{filepath.Join(p.moduleAbs(pkg), "config.go"), configTemplate, true},
+		{filepath.Join(p.moduleAbs(pkg), "module.go"), moduleTemplate, true},
+	}
+	for _, f := range files {
+		if err := writeTemplate(f.path, f.tmpl, data, f.goCode); err != nil {
+			return err
+		}
+		slog.Info("Created file", "path", f.path)
+	}
+	// endpoint 及 contract 中的代码由 gen 生成
+	return p.gen(pkg)
+}
+
+// writeTemplate 渲染模板并写入文件，goCode 为 true 时格式化代码
+func writeTemplate(filePath, text string, data any, goCode bool) error {
+	tmpl, err := template.New(filepath.Base(filePath)).Parse(text)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	content := buf.Bytes()
+	if goCode {
+		if content, err = format.Source(content); err != nil {
+			return err
+		}
+	}
+	if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, content, 0644)
+}
@@ -0,0 +1,32 @@
+// protoc-gen-gmicor 生成 g-micor 模块代码的 protoc 插件
+//
+//	protoc --go_out=. --go-grpc_out=. --gmicor_out=. \
+//	  --gmicor_opt=paths=source_relative,endpoint=example.com/shop/module/user/endpoint,endpoint_dir=module/user/endpoint \
+//	  contract/userC/user.proto
+//
+// 参数:
+//
+//	endpoint      endpoint 包的导入路径，为空时只生成 contract_gen.go
+//	endpoint_dir  endpoint 包相对 --gmicor_out 的目录
+//
+// 服务端实现骨架 rpcServer.go 需要合并已有代码，由 gmicor gen 生成
+package main
+
+import (
+	"flag"
+
+	"github.com/Gong-Yang/g-micor/internal/generator"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func main() {
+	var flags flag.FlagSet
+	endpoint := flags.String("endpoint", "", "endpoint 包的导入路径")
+	endpointDir := flags.String("endpoint_dir", "", "endpoint 包的输出目录")
+	protogen.Options{ParamFunc: flags.Set}.Run(func(gen *protogen.Plugin) error {
+		return generator.Run(gen, generator.Config{
+			EndpointDir:    *endpointDir,
+			EndpointImport: protogen.GoImportPath(*endpoint),
+		})
+	})
+}
@@ -0,0 +1,170 @@
+package discover
+
+import (
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+//go:embed admin.html
+var adminPage []byte
+
+// AdminState 管理后台展示的注册中心状态
+type AdminState struct {
+	BootID    string          `json:"bootId"`
+	Services  []AdminService  `json:"services"`
+	Instances []AdminInstance `json:"instances"`
+}
+
+type AdminService struct {
+	Name        string   `json:"name"`
+	Instances   []string `json:"instances"`
+	Subscribers []string `json:"subscribers"`
+}
+
+type AdminInstance struct {
+	Addr     string    `json:"addr"`
+	Servers  []string  `json:"servers"`
+	Metadata *Metadata `json:"metadata,omitempty"`
+	Status   string    `json:"status"`
+	LastPing time.Time `json:"lastPing,omitzero"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// AdminTokenHeader 管理后台接口携带令牌的请求头
+// 未配置令牌时也要求携带该请求头，浏览器跨站请求无法自定义请求头，避免被其他页面伪造调用
+const AdminTokenHeader = "X-Admin-Token"
+
+// adminListenAddr 只指定端口时监听在本机回环地址上
+func adminListenAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("127.0.0.1", port)
+}
+
+// startAdmin 启动管理后台
+func (s *Service) startAdmin(addr string) {
+	addr = adminListenAddr(addr)
+	slog.Info("注册中心管理后台启动", "addr", addr, "token", s.adminToken != "")
+	if err := http.ListenAndServe(addr, s.adminHandler()); err != nil {
+		slog.Error("注册中心管理后台启动失败", "addr", addr, "err", err)
+	}
+}
+
+// adminHandler 管理后台的接口
+//
+//	GET  /                    状态页面
+//	GET  /api/state           服务、实例、订阅者及健康状态，配置了令牌时需携带令牌
+//	POST /api/evict?addr=     剔除实例并通知订阅者
+//	POST /api/notify?server=  强制通知订阅者重新拉取服务地址
+func (s *Service) adminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(adminPage)
+	})
+	state := func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.adminState())
+	}
+	// 配置了令牌时，查看状态同样需要令牌，避免泄露服务拓扑和实例地址
+	if s.adminToken != "" {
+		state = s.adminAuth(state)
+	}
+	mux.HandleFunc("GET /api/state", state)
+	mux.HandleFunc("POST /api/evict", s.adminAuth(func(w http.ResponseWriter, r *http.Request) {
+		addr := r.URL.Query().Get("addr")
+		s.lock.RLock()
+		_, ok := s.sAddrToSNames[addr]
+		s.lock.RUnlock()
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "instance not found"})
+			return
+		}
+		slog.Warn("管理后台剔除实例", "addr", addr, "remote", r.RemoteAddr)
+		s.removeInstance(addr)
+		writeJSON(w, http.StatusOK, map[string]string{"addr": addr})
+	}))
+	mux.HandleFunc("POST /api/notify", s.adminAuth(func(w http.ResponseWriter, r *http.Request) {
+		server := r.URL.Query().Get("server")
+		if server == "" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "server is required"})
+			return
+		}
+		slog.Info("管理后台强制通知", "server", server, "remote", r.RemoteAddr)
+		s.notifySubscribers(&NotifyReq{Type: "refresh", Server: server})
+		writeJSON(w, http.StatusOK, map[string]string{"server": server})
+	}))
+	return mux
+}
+
+// adminAuth 校验管理接口的令牌，请求头缺失或与 WithAdminToken 配置的令牌不一致时返回 401
+func (s *Service) adminAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := r.Header[http.CanonicalHeaderKey(AdminTokenHeader)]
+		if !ok || subtle.ConstantTimeCompare([]byte(token[0]), []byte(s.adminToken)) != 1 {
+			slog.Warn("管理后台鉴权失败", "path", r.URL.Path, "remote", r.RemoteAddr)
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid admin token"})
+			return
+		}
+		next(w, r)
+	}
+}
+
+// adminState 汇总当前状态，按名称排序保证页面展示稳定
+func (s *Service) adminState() *AdminState {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	state := &AdminState{BootID: s.bootID}
+
+	names := make(map[string]struct{})
+	for name := range s.sNameToSAddr {
+		names[name] = struct{}{}
+	}
+	for name := range s.sNameToDAddr {
+		names[name] = struct{}{}
+	}
+	for name := range names {
+		state.Services = append(state.Services, AdminService{
+			Name:        name,
+			Instances:   slices.Clone(s.sNameToSAddr[name]),
+			Subscribers: slices.Clone(s.sNameToDAddr[name]),
+		})
+	}
+	slices.SortFunc(state.Services, func(a, b AdminService) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for addr, servers := range s.sAddrToSNames {
+		instance := AdminInstance{
+			Addr:     addr,
+			Servers:  slices.Clone(servers),
+			Metadata: s.sAddrToMeta[addr],
+		}
+		if h := s.health[addr]; h != nil {
+			instance.Status = h.Status
+			instance.LastPing = h.LastPing
+			instance.Error = h.Error
+		}
+		state.Instances = append(state.Instances, instance)
+	}
+	slices.SortFunc(state.Instances, func(a, b AdminInstance) int {
+		return strings.Compare(a.Addr, b.Addr)
+	})
+	return state
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("写入响应失败", "err", err)
+	}
+}
@@ -0,0 +1,88 @@
+package discover
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestAdminAuth(t *testing.T) {
+	s := &Service{
+		lock:          &sync.RWMutex{},
+		sAddrToSNames: make(map[string][]string),
+	}
+	evict := func(header map[string]string) int {
+		r := httptest.NewRequest(http.MethodPost, "/api/evict?addr=10.0.0.1:9000", nil)
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		s.adminHandler().ServeHTTP(w, r)
+		return w.Code
+	}
+	tests := []struct {
+		name   string
+		token  string
+		header map[string]string
+		want   int
+	}{
+		{"未配置令牌缺少请求头", "", nil, http.StatusUnauthorized},
+		{"未配置令牌携带请求头", "", map[string]string{AdminTokenHeader: ""}, http.StatusNotFound},
+		{"令牌错误", "secret", map[string]string{AdminTokenHeader: "wrong"}, http.StatusUnauthorized},
+		{"令牌正确", "secret", map[string]string{AdminTokenHeader: "secret"}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.adminToken = tt.token
+			if got := evict(tt.header); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminStateAuth(t *testing.T) {
+	s := &Service{lock: &sync.RWMutex{}}
+	state := func(header map[string]string) int {
+		r := httptest.NewRequest(http.MethodGet, "/api/state", nil)
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		s.adminHandler().ServeHTTP(w, r)
+		return w.Code
+	}
+	tests := []struct {
+		name   string
+		token  string
+		header map[string]string
+		want   int
+	}{
+		{"未配置令牌", "", nil, http.StatusOK},
+		{"缺少令牌", "secret", nil, http.StatusUnauthorized},
+		{"令牌错误", "secret", map[string]string{AdminTokenHeader: "wrong"}, http.StatusUnauthorized},
+		{"令牌正确", "secret", map[string]string{AdminTokenHeader: "secret"}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.adminToken = tt.token
+			if got := state(tt.header); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminListenAddr(t *testing.T) {
+	tests := map[string]string{
+		":8081":         "127.0.0.1:8081",
+		"0.0.0.0:8081":  "0.0.0.0:8081",
+		"10.0.0.1:8081": "10.0.0.1:8081",
+	}
+	for addr, want := range tests {
+		if got := adminListenAddr(addr); got != want {
+			t.Errorf("adminListenAddr(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package discover
+
+import (
+	"context"
+	"hash/crc32"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+// 负载均衡策略，通过 WithBalancer 指定
+const (
+	BalancerRoundRobin   = "round_robin"          // 轮询(默认)
+	BalancerWeighted     = "gmicor_weighted"      // 按实例权重平滑加权轮询
+	BalancerHash         = "gmicor_hash"          // 按 WithHashKey 设置的key一致性哈希
+	BalancerLeastRequest = "gmicor_least_request" // 优先选择进行中请求最少的实例
+)
+
+// hashReplicas 一致性哈希环上每个实例的虚拟节点数
+const hashReplicas = 160
+
+func init() {
+	balancer.Register(base.NewBalancerBuilder(BalancerWeighted, weightedPickerBuilder{}, base.Config{HealthCheck: true}))
+	balancer.Register(base.NewBalancerBuilder(BalancerHash, hashPickerBuilder{}, base.Config{HealthCheck: true}))
+	balancer.Register(leastRequestBuilder{})
+}
+
+// WithBalancer 指定负载均衡策略，如 BalancerWeighted
+func WithBalancer(name string) GrpcOption {
+	return func(o *grpcOptions) {
+		o.balancer = name
+	}
+}
+
+type hashKey struct{}
+
+// WithHashKey 设置一致性哈希的key，相同key的请求总是落到同一实例上
+// 仅在 BalancerHash 策略下生效，未设置时随机选择实例
+func WithHashKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, hashKey{}, key)
+}
+
+func hashKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(hashKey{}).(string)
+	return key, ok
+}
+
+// weightedPickerBuilder 平滑加权轮询
+type weightedPickerBuilder struct{}
+
+func (weightedPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	p := &weightedPicker{}
+	for sc, scInfo := range info.ReadySCs {
+		weight := int(MetadataFromAddress(scInfo.Address).GetWeightOrDefault())
+		p.nodes = append(p.nodes, &weightedNode{subConn: sc, weight: weight})
+		p.total += weight
+	}
+	return p
+}
+
+type weightedNode struct {
+	subConn balancer.SubConn
+	weight  int
+	current int
+}
+
+type weightedPicker struct {
+	lock  sync.Mutex
+	nodes []*weightedNode
+	total int
+}
+
+func (p *weightedPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var best *weightedNode
+	for _, node := range p.nodes {
+		node.current += node.weight
+		if best == nil || node.current > best.current {
+			best = node
+		}
+	}
+	best.current -= p.total
+	return balancer.PickResult{SubConn: best.subConn}, nil
+}
+
+// hashPickerBuilder 一致性哈希
+type hashPickerBuilder struct{}
+
+func (hashPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	p := &hashPicker{nodes: make(map[uint32]balancer.SubConn)}
+	for sc, scInfo := range info.ReadySCs {
+		p.subConns = append(p.subConns, sc)
+		for i := range hashReplicas {
+			h := crc32.ChecksumIEEE([]byte(scInfo.Address.Addr + "#" + strconv.Itoa(i)))
+			p.ring = append(p.ring, h)
+			p.nodes[h] = sc
+		}
+	}
+	slices.Sort(p.ring)
+	return p
+}
+
+type hashPicker struct {
+	ring     []uint32
+	nodes    map[uint32]balancer.SubConn
+	subConns []balancer.SubConn
+}
+
+func (p *hashPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	key, ok := hashKeyFromContext(info.Ctx)
+	if !ok {
+		return balancer.PickResult{SubConn: p.subConns[rand.IntN(len(p.subConns))]}, nil
+	}
+	h := crc32.ChecksumIEEE([]byte(key))
+	// 顺时针找到第一个虚拟节点
+	i, _ := slices.BinarySearch(p.ring, h)
+	if i == len(p.ring) {
+		i = 0
+	}
+	return balancer.PickResult{SubConn: p.nodes[p.ring[i]]}, nil
+}
+
+// leastRequestBuilder 最少进行中请求，每个连接使用独立的计数
+type leastRequestBuilder struct{}
+
+func (leastRequestBuilder) Name() string {
+	return BalancerLeastRequest
+}
+
+func (leastRequestBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	return base.NewBalancerBuilder(BalancerLeastRequest, newLeastRequestPickerBuilder(), base.Config{HealthCheck: true}).Build(cc, opts)
+}
+
+// leastRequestPickerBuilder 实例状态变化时会重建 picker，进行中请求数按 SubConn 保存在 builder 中，重建后沿用
+type leastRequestPickerBuilder struct {
+	lock     sync.Mutex
+	inflight map[balancer.SubConn]*atomic.Int64
+}
+
+func newLeastRequestPickerBuilder() *leastRequestPickerBuilder {
+	return &leastRequestPickerBuilder{inflight: make(map[balancer.SubConn]*atomic.Int64)}
+}
+
+func (b *leastRequestPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	// 不再可用且没有进行中请求的实例不再计数
+	for sc, inflight := range b.inflight {
+		if _, ok := info.ReadySCs[sc]; !ok && inflight.Load() == 0 {
+			delete(b.inflight, sc)
+		}
+	}
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	p := &leastRequestPicker{}
+	for sc := range info.ReadySCs {
+		inflight := b.inflight[sc]
+		if inflight == nil {
+			inflight = &atomic.Int64{}
+			b.inflight[sc] = inflight
+		}
+		p.nodes = append(p.nodes, &leastRequestNode{subConn: sc, inflight: inflight})
+	}
+	return p
+}
+
+type leastRequestNode struct {
+	subConn  balancer.SubConn
+	inflight *atomic.Int64
+}
+
+type leastRequestPicker struct {
+	nodes []*leastRequestNode
+	next  atomic.Uint32
+}
+
+func (p *leastRequestPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	// 从轮转的起点开始扫描，进行中请求数相同时均匀分布
+	start := int(p.next.Add(1)) % len(p.nodes)
+	best := p.nodes[start]
+	for i := 1; i < len(p.nodes); i++ {
+		node := p.nodes[(start+i)%len(p.nodes)]
+		if node.inflight.Load() < best.inflight.Load() {
+			best = node
+		}
+	}
+	best.inflight.Add(1)
+	return balancer.PickResult{
+		SubConn: best.subConn,
+		Done: func(balancer.DoneInfo) {
+			best.inflight.Add(-1)
+		},
+	}, nil
+}
@@ -0,0 +1,128 @@
+package discover
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/resolver"
+)
+
+type fakeSubConn struct {
+	balancer.SubConn
+	addr string
+}
+
+func buildInfo(weights map[string]int32) base.PickerBuildInfo {
+	info := base.PickerBuildInfo{ReadySCs: make(map[balancer.SubConn]base.SubConnInfo)}
+	for addr, weight := range weights {
+		info.ReadySCs[&fakeSubConn{addr: addr}] = base.SubConnInfo{Address: resolver.Address{
+			Addr:       addr,
+			Attributes: attributes.New(metadataKey{}, metadataAttr{&Metadata{Weight: weight}}),
+		}}
+	}
+	return info
+}
+
+func pickAddr(t *testing.T, p balancer.Picker, ctx context.Context) string {
+	res, err := p.Pick(balancer.PickInfo{Ctx: ctx})
+	if err != nil {
+		t.Fatalf("Pick() error = %v", err)
+	}
+	return res.SubConn.(*fakeSubConn).addr
+}
+
+func TestWeightedPicker(t *testing.T) {
+	t.Run("按权重分配", func(t *testing.T) {
+		p := weightedPickerBuilder{}.Build(buildInfo(map[string]int32{"a": 5, "b": 1, "c": 1}))
+		counts := map[string]int{}
+		for range 70 {
+			counts[pickAddr(t, p, context.Background())]++
+		}
+		if counts["a"] != 50 || counts["b"] != 10 || counts["c"] != 10 {
+			t.Errorf("counts = %v, expected a:50 b:10 c:10", counts)
+		}
+	})
+
+	t.Run("未设置权重按默认权重", func(t *testing.T) {
+		p := weightedPickerBuilder{}.Build(buildInfo(map[string]int32{"a": 0, "b": DefaultWeight}))
+		counts := map[string]int{}
+		for range 10 {
+			counts[pickAddr(t, p, context.Background())]++
+		}
+		if counts["a"] != 5 || counts["b"] != 5 {
+			t.Errorf("counts = %v, expected a:5 b:5", counts)
+		}
+	})
+}
+
+func TestHashPicker(t *testing.T) {
+	t.Run("相同key选择相同实例", func(t *testing.T) {
+		p := hashPickerBuilder{}.Build(buildInfo(map[string]int32{"a": 0, "b": 0, "c": 0}))
+		ctx := WithHashKey(context.Background(), "user-1")
+		expected := pickAddr(t, p, ctx)
+		for range 20 {
+			if addr := pickAddr(t, p, ctx); addr != expected {
+				t.Fatalf("Pick() = %v, expected %v", addr, expected)
+			}
+		}
+	})
+
+	t.Run("实例下线只影响该实例上的key", func(t *testing.T) {
+		full := hashPickerBuilder{}.Build(buildInfo(map[string]int32{"a": 0, "b": 0, "c": 0}))
+		partial := hashPickerBuilder{}.Build(buildInfo(map[string]int32{"a": 0, "b": 0}))
+		for _, key := range []string{"k1", "k2", "k3", "k4", "k5", "k6", "k7", "k8"} {
+			ctx := WithHashKey(context.Background(), key)
+			before := pickAddr(t, full, ctx)
+			after := pickAddr(t, partial, ctx)
+			if before != "c" && before != after {
+				t.Errorf("key %s moved from %s to %s", key, before, after)
+			}
+		}
+	})
+}
+
+func TestLeastRequestPicker(t *testing.T) {
+	t.Run("优先选择进行中请求最少的实例", func(t *testing.T) {
+		p := newLeastRequestPickerBuilder().Build(buildInfo(map[string]int32{"a": 0, "b": 0}))
+		first, err := p.Pick(balancer.PickInfo{Ctx: context.Background()})
+		if err != nil {
+			t.Fatalf("Pick() error = %v", err)
+		}
+		busy := first.SubConn.(*fakeSubConn).addr
+		if addr := pickAddr(t, p, context.Background()); addr == busy {
+			t.Errorf("Pick() = %v, expected the idle instance", addr)
+		}
+	})
+	t.Run("重建 picker 后沿用进行中请求数", func(t *testing.T) {
+		b := newLeastRequestPickerBuilder()
+		info := buildInfo(map[string]int32{"a": 0, "b": 0})
+		p := b.Build(info)
+		// 保持一个进行中请求
+		held, err := p.Pick(balancer.PickInfo{Ctx: context.Background()})
+		if err != nil {
+			t.Fatalf("Pick() error = %v", err)
+		}
+		busy := held.SubConn.(*fakeSubConn).addr
+		// 其它实例状态变化触发重建
+		p = b.Build(info)
+		for range 4 {
+			res, err := p.Pick(balancer.PickInfo{Ctx: context.Background()})
+			if err != nil {
+				t.Fatalf("Pick() error = %v", err)
+			}
+			if addr := res.SubConn.(*fakeSubConn).addr; addr == busy {
+				t.Fatalf("Pick() = %v, expected the idle instance after rebuild", addr)
+			}
+			res.Done(balancer.DoneInfo{})
+		}
+		held.Done(balancer.DoneInfo{})
+		for sc, inflight := range b.inflight {
+			if n := inflight.Load(); n != 0 {
+				t.Errorf("%s inflight = %d after all requests done", sc.(*fakeSubConn).addr, n)
+			}
+		}
+	})
+}
@@ -0,0 +1,304 @@
+package discover
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	heartbeatInterval  = 10 * time.Second // 客户端向注册中心发送心跳的间隔
+	watchRetryInterval = time.Second      // Watch 流断开后的重连间隔
+)
+
+// centerRegistry 基于自建注册中心(discover.Run)的实现
+// 每个订阅的服务保持一个 Watch 流，由注册中心推送实例快照
+// 注册中心不支持 Watch 时，退回由注册中心反向调用 ClientService.SubscribeServerRegister 通知变更
+// 客户端定时心跳，发现注册中心重启且丢失注册信息时自动重新注册并恢复订阅
+type centerRegistry struct {
+	conn   *grpc.ClientConn
+	client RegisterClient
+	port   string // 本节点RPC端口
+	addr   string // 本节点对外地址，为空时由注册中心推断
+	req    *RegisterReq
+	bootID string
+	stop   context.CancelFunc
+
+	lock     sync.Mutex
+	watchers map[string]map[*watcher]struct{} // 服务名 -> 订阅者
+	streams  map[string]context.CancelFunc    // 服务名 -> Watch 流
+	latest   map[string][]*Instance           // 服务名 -> 最近一次推送的实例
+	legacy   atomic.Bool                      // 注册中心不支持 Watch，使用反向通知
+}
+
+type watcher struct {
+	notify func(instances []*Instance)
+}
+
+// NewCenterRegistry 创建连接自建注册中心的 Registry
+// addr 可以是逗号分隔的多个注册中心地址，当前注册中心不可用时自动切换到下一个
+func NewCenterRegistry(addr string) (Registry, error) {
+	target := addr
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(clientCreds)}
+	if addrs := strings.Split(addr, ","); len(addrs) > 1 {
+		r := manual.NewBuilderWithScheme("g-micor-center")
+		state := resolver.State{}
+		for _, a := range addrs {
+			state.Addresses = append(state.Addresses, resolver.Address{Addr: strings.TrimSpace(a)})
+		}
+		r.InitialState(state)
+		target = r.Scheme() + ":///center"
+		opts = append(opts, grpc.WithResolvers(r))
+	}
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		slog.Error("failed to create grpc client", "error", err)
+		return nil, err
+	}
+	return &centerRegistry{
+		conn:     conn,
+		client:   NewRegisterClient(conn),
+		watchers: make(map[string]map[*watcher]struct{}),
+		streams:  make(map[string]context.CancelFunc),
+		latest:   make(map[string][]*Instance),
+	}, nil
+}
+
+func (c *centerRegistry) Register(ctx context.Context, req *RegisterReq) error {
+	res, err := c.client.Register(ctx, req)
+	if err != nil {
+		slog.Error("register error", "error", err)
+		return err
+	}
+	c.port = req.Port
+	c.addr = req.Addr
+	c.req = req
+	c.bootID = res.GetBootId()
+
+	heartbeatCtx, cancel := context.WithCancel(context.Background())
+	c.stop = cancel
+	go c.heartbeat(heartbeatCtx)
+	return nil
+}
+
+// heartbeat 定时心跳，感知注册中心重启
+func (c *centerRegistry) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		res, err := c.client.Heartbeat(ctx, &HeartbeatReq{Port: c.port, Addr: c.addr})
+		if ctx.Err() != nil {
+			// 已注销
+			return
+		}
+		if err != nil {
+			slog.Warn("center heartbeat error", "error", err)
+			setRegistered(err)
+			continue
+		}
+		if res.Registered && res.BootId == c.bootID {
+			setRegistered(nil)
+			continue
+		}
+		slog.Warn("center restarted", "oldBootID", c.bootID, "bootID", res.BootId, "registered", res.Registered)
+		if !res.Registered {
+			// 注册信息已丢失，重新注册
+			reg, err := c.client.Register(ctx, c.req)
+			if err != nil {
+				slog.Error("re-register error", "error", err)
+				setRegistered(err)
+				continue
+			}
+			res.BootId = reg.GetBootId()
+			slog.Info("re-register success", "servers", c.req.Servers)
+		}
+		c.bootID = res.BootId
+		setRegistered(nil)
+		// Watch 流会自动重连并收到全量快照，反向通知模式下需重新发现以恢复注册中心上的订阅关系
+		if c.legacy.Load() {
+			c.refreshAll()
+		}
+	}
+}
+
+func (c *centerRegistry) Deregister(ctx context.Context) error {
+	if c.stop != nil {
+		c.stop()
+	}
+	_, err := c.client.Deregister(ctx, &DeregisterReq{Port: c.port, Addr: c.addr})
+	return err
+}
+
+func (c *centerRegistry) Discover(ctx context.Context, server string) ([]*Instance, error) {
+	// 反向通知模式下，发现的同时注册中心会记录订阅关系；使用 Watch 流时只查询
+	res, err := c.client.Discover(ctx, &Req{
+		Port:        c.port,
+		Server:      server,
+		Addr:        c.addr,
+		NoSubscribe: !c.legacy.Load(),
+	})
+	if err != nil {
+		// 服务的实例已全部下线
+		if status.Convert(err).Message() == ErrServerNotFind.Error() {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(res.Instances) > 0 {
+		return res.Instances, nil
+	}
+	// 旧版注册中心只返回地址
+	instances := make([]*Instance, len(res.Addr))
+	for i, addr := range res.Addr {
+		instances[i] = &Instance{Addr: addr}
+	}
+	return instances, nil
+}
+
+func (c *centerRegistry) Watch(ctx context.Context, server string, notify func(instances []*Instance)) error {
+	w := &watcher{notify: notify}
+	c.lock.Lock()
+	if c.watchers[server] == nil {
+		c.watchers[server] = make(map[*watcher]struct{})
+	}
+	c.watchers[server][w] = struct{}{}
+	if !c.legacy.Load() && c.streams[server] == nil {
+		streamCtx, cancel := context.WithCancel(context.Background())
+		c.streams[server] = cancel
+		go c.watchStream(streamCtx, server)
+	}
+	latest, ok := c.latest[server]
+	c.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.lock.Lock()
+		delete(c.watchers[server], w)
+		if len(c.watchers[server]) == 0 {
+			delete(c.watchers, server)
+			// 最后一个订阅者取消时关闭 Watch 流
+			if cancel := c.streams[server]; cancel != nil {
+				cancel()
+				delete(c.streams, server)
+				delete(c.latest, server)
+			}
+		}
+		c.lock.Unlock()
+	}()
+
+	if c.legacy.Load() {
+		c.refresh(server)
+	} else if ok {
+		// 流已建立，直接使用最近一次推送的实例
+		notify(latest)
+	}
+	return nil
+}
+
+// watchStream 保持服务的 Watch 流，断开后自动重连
+// 重连后注册中心会立即推送全量快照，注册中心重启也无需额外处理
+func (c *centerRegistry) watchStream(ctx context.Context, server string) {
+	for {
+		err := c.recvStream(ctx, server)
+		if ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			slog.Warn("center does not support watch, fallback to reverse notify", "server", server)
+			c.legacy.Store(true)
+			c.refresh(server)
+			return
+		}
+		slog.Warn("center watch stream broken", "server", server, "error", err)
+		select {
+		case <-time.After(watchRetryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *centerRegistry) recvStream(ctx context.Context, server string) error {
+	stream, err := c.client.Watch(ctx, &WatchReq{Server: server})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		c.dispatch(server, res.Instances)
+	}
+}
+
+// dispatch 记录并分发推送的实例
+func (c *centerRegistry) dispatch(server string, instances []*Instance) {
+	c.lock.Lock()
+	c.latest[server] = instances
+	ws := make([]*watcher, 0, len(c.watchers[server]))
+	for w := range c.watchers[server] {
+		ws = append(ws, w)
+	}
+	c.lock.Unlock()
+	for _, w := range ws {
+		w.notify(instances)
+	}
+}
+
+// refresh 重新拉取服务地址并通知订阅者
+func (c *centerRegistry) refresh(server string) {
+	c.lock.Lock()
+	ws := make([]*watcher, 0, len(c.watchers[server]))
+	for w := range c.watchers[server] {
+		ws = append(ws, w)
+	}
+	c.lock.Unlock()
+	if len(ws) == 0 {
+		slog.Error("server not find", "server", server)
+		return
+	}
+
+	instances, err := c.Discover(context.Background(), server)
+	if err != nil {
+		slog.Error("discover error", "server", server, "error", err)
+		return
+	}
+	for _, w := range ws {
+		w.notify(instances)
+	}
+}
+
+// refreshAll 刷新所有订阅的服务
+func (c *centerRegistry) refreshAll() {
+	c.lock.Lock()
+	servers := make([]string, 0, len(c.watchers))
+	for server := range c.watchers {
+		servers = append(servers, server)
+	}
+	c.lock.Unlock()
+	for _, server := range servers {
+		c.refresh(server)
+	}
+}
+
+func (c *centerRegistry) Close() error {
+	if c.stop != nil {
+		c.stop()
+	}
+	return c.conn.Close()
+}
@@ -3,21 +3,33 @@ package discover
 import (
 	"context"
 	"fmt"
+	"github.com/Gong-Yang/g-micor/rpcx"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
 	"log"
 	"log/slog"
+	"net/url"
 )
 
-// Grpc 发现服务地址
-func Grpc(server string) (c grpc.ClientConnInterface, err error) {
-	conn, err := grpc.NewClient(
-		fmt.Sprintf("%s:///%s", "g-micor", server),
-		// 通过服务配置设置负载均衡策略为round_robin
-		grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"round_robin":{}}]}`), // 设置初始负载均衡策略
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+// Grpc 发现服务地址，可通过 opts 按版本、区域、标签筛选实例及选择负载均衡策略
+func Grpc(server string, opts ...GrpcOption) (c grpc.ClientConnInterface, err error) {
+	o := &grpcOptions{query: url.Values{}, balancer: BalancerRoundRobin}
+	for _, opt := range opts {
+		opt(o)
+	}
+	target := fmt.Sprintf("%s:///%s", "g-micor", server)
+	if len(o.query) > 0 {
+		target += "?" + o.query.Encode()
+	}
+	dialOpts := append([]grpc.DialOption{
+		// 通过服务配置设置负载均衡策略，默认round_robin
+		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingConfig": [{"%s":{}}]}`, o.balancer)),
+		grpc.WithTransportCredentials(clientCreds),
+	}, rpcx.DefaultChain.DialOptions()...)
+	conn, err := grpc.NewClient(target, dialOpts...)
 	if err != nil {
 		log.Fatalf("连接失败: %v", err)
 	}
@@ -26,75 +38,63 @@ func Grpc(server string) (c grpc.ClientConnInterface, err error) {
 
 type ClientService struct {
 	UnimplementedClientServer
+	// Check 实例的就绪检查，返回错误时 Ping 返回 Unavailable，为空时总是成功
+	Check func(ctx context.Context) error
 }
 
+// Ping 返回实例的就绪状态，注册中心在实例未实现 grpc.health.v1 时以此做健康检查
 func (c ClientService) Ping(ctx context.Context, req *PingReq) (*PingRes, error) {
-	return nil, nil
+	if c.Check != nil {
+		if err := c.Check(ctx); err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+	}
+	return &PingRes{}, nil
 }
 
 // SubscribeServerRegister 客户端订阅的服务发生了注册或者下线
 func (c ClientService) SubscribeServerRegister(ctx context.Context, req *NotifyReq) (*NotifyRes, error) {
 	slog.Info("SubscribeServerRegister", "info", req)
 	// 触发重新解析
-	r, ok := resolverMap[req.Server]
+	center, ok := registry.(*centerRegistry)
 	if !ok {
-		slog.Error("server not find", "server", req.Server)
+		slog.Error("registry is not center", "server", req.Server)
 		return nil, nil
 	}
-	r.updateStates()
+	center.refresh(req.Server)
 	return nil, nil
 }
 func init() {
 	resolver.Register(&resolverBuilder{})
 }
 
-var resolverMap = make(map[string]*resolve)
-var localPort = ""
-
-var centerClient RegisterClient
-
-func RegisterCenter(addr string, req *RegisterReq) {
-	//向注册中心发起注册
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		slog.Error("failed to create grpc client", "error", err)
-		panic(err)
-	}
-	centerClient = NewRegisterClient(conn)
-	_, err = centerClient.Register(context.Background(), req)
-	if err != nil {
-		slog.Error("register error", "error", err)
-		panic(err)
-	}
-	localPort = req.Port
-}
-
 type resolve struct {
 	target resolver.Target
+	filter instanceFilter
 	cc     resolver.ClientConn
+	cancel context.CancelFunc
 }
 
 func (r *resolve) ResolveNow(options resolver.ResolveNowOptions) {
-	r.updateStates()
-}
-
-func (r *resolve) Close() {
-	return
-}
-func (r *resolve) updateStates() {
-	target := r.target
-	endpoint := target.Endpoint()
-	discover, err := centerClient.Discover(context.Background(), &Req{
-		Port:   localPort,
-		Server: endpoint,
-	})
+	instances, err := registry.Discover(context.Background(), r.target.Endpoint())
 	if err != nil {
 		slog.Error("discover error", "error", err)
 		return
 	}
-	addrs := make([]resolver.Address, len(discover.Addr))
-	for i, addr := range discover.Addr {
-		addrs[i] = resolver.Address{Addr: addr}
+	r.updateStates(instances)
+}
+
+func (r *resolve) Close() {
+	r.cancel()
+}
+func (r *resolve) updateStates(instances []*Instance) {
+	instances = r.filter.filter(instances)
+	addrs := make([]resolver.Address, len(instances))
+	for i, instance := range instances {
+		addrs[i] = resolver.Address{
+			Addr:       instance.Addr,
+			Attributes: attributes.New(metadataKey{}, metadataAttr{instance.GetMetadata()}),
+		}
 	}
 	r.cc.UpdateState(resolver.State{
 		Addresses: addrs,
@@ -105,14 +105,23 @@ type resolverBuilder struct {
 }
 
 func (r *resolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
-	slog.Info("resolver Build")
-	target.Endpoint()
+	slog.Info("resolver Build", "server", target.Endpoint())
+	if registry == nil {
+		return nil, ErrRegistryNotInit
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	r2 := &resolve{
 		target: target,
+		filter: newInstanceFilter(target),
 		cc:     cc,
+		cancel: cancel,
+	}
+	// 订阅服务变化，首次订阅会立即推送当前地址
+	err := registry.Watch(ctx, target.Endpoint(), r2.updateStates)
+	if err != nil {
+		cancel()
+		return nil, err
 	}
-	resolverMap[target.Endpoint()] = r2
-	r2.updateStates()
 	return r2, nil
 }
 
@@ -24,8 +24,10 @@ const (
 // 服务发现请求
 type Req struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Port          string                 `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`     // 请求者的地址
-	Server        string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"` // 请求者需要发现的服务
+	Port          string                 `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`                                   // 请求者的地址
+	Server        string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`                               // 请求者需要发现的服务
+	Addr          string                 `protobuf:"bytes,3,opt,name=addr,proto3" json:"addr,omitempty"`                                   // 请求者对外地址，为空时由注册中心根据对端IP和port推断
+	NoSubscribe   bool                   `protobuf:"varint,4,opt,name=no_subscribe,json=noSubscribe,proto3" json:"no_subscribe,omitempty"` // 只查询，不记录订阅关系，使用 Watch 流的客户端不需要反向通知
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,11 +76,26 @@ func (x *Req) GetServer() string {
 	return ""
 }
 
+func (x *Req) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Req) GetNoSubscribe() bool {
+	if x != nil {
+		return x.NoSubscribe
+	}
+	return false
+}
+
 // 服务发现响应
 type Resp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Server        string                 `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"` // 服务名称
-	Addr          []string               `protobuf:"bytes,2,rep,name=addr,proto3" json:"addr,omitempty"`     // 服务地址
+	Server        string                 `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`       // 服务名称
+	Addr          []string               `protobuf:"bytes,2,rep,name=addr,proto3" json:"addr,omitempty"`           // 服务地址
+	Instances     []*Instance            `protobuf:"bytes,3,rep,name=instances,proto3" json:"instances,omitempty"` // 服务实例，包含元数据
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -127,18 +144,165 @@ func (x *Resp) GetAddr() []string {
 	return nil
 }
 
+func (x *Resp) GetInstances() []*Instance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+// 实例元数据
+type Metadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`                       // 版本号，用于灰度路由
+	Zone          string                 `protobuf:"bytes,2,opt,name=zone,proto3" json:"zone,omitempty"`                             // 可用区，用于就近访问
+	Weight        int32                  `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`                        // 权重，用于加权负载均衡
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`                             // 标签
+	StartTime     int64                  `protobuf:"varint,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 启动时间，unix毫秒
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_g_micor_discover_discover_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_g_micor_discover_discover_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Metadata) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Metadata) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *Metadata) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Metadata) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Metadata) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+// 服务实例
+type Instance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`         // 实例地址
+	Metadata      *Metadata              `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"` // 实例元数据
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`     // 健康状态 healthy/suspect/draining/down，为空视为 healthy
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Instance) Reset() {
+	*x = Instance{}
+	mi := &file_g_micor_discover_discover_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Instance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instance) ProtoMessage() {}
+
+func (x *Instance) ProtoReflect() protoreflect.Message {
+	mi := &file_g_micor_discover_discover_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instance.ProtoReflect.Descriptor instead.
+func (*Instance) Descriptor() ([]byte, []int) {
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Instance) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Instance) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Instance) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 // 服务注册请求
 type RegisterReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Port          string                 `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
-	Servers       []string               `protobuf:"bytes,3,rep,name=servers,proto3" json:"servers,omitempty"` // 服务列表
+	Servers       []string               `protobuf:"bytes,3,rep,name=servers,proto3" json:"servers,omitempty"`   // 服务列表
+	Metadata      *Metadata              `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"` // 实例元数据
+	Addr          string                 `protobuf:"bytes,5,opt,name=addr,proto3" json:"addr,omitempty"`         // 对外地址 host:port，为空时由注册中心根据对端IP和port推断
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegisterReq) Reset() {
 	*x = RegisterReq{}
-	mi := &file_g_micor_discover_discover_proto_msgTypes[2]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -150,7 +314,7 @@ func (x *RegisterReq) String() string {
 func (*RegisterReq) ProtoMessage() {}
 
 func (x *RegisterReq) ProtoReflect() protoreflect.Message {
-	mi := &file_g_micor_discover_discover_proto_msgTypes[2]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -163,7 +327,7 @@ func (x *RegisterReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterReq.ProtoReflect.Descriptor instead.
 func (*RegisterReq) Descriptor() ([]byte, []int) {
-	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{2}
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RegisterReq) GetPort() string {
@@ -180,16 +344,31 @@ func (x *RegisterReq) GetServers() []string {
 	return nil
 }
 
+func (x *RegisterReq) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RegisterReq) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
 // 服务注册响应
 type RegisterRes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	BootId        string                 `protobuf:"bytes,1,opt,name=boot_id,json=bootId,proto3" json:"boot_id,omitempty"` // 注册中心本次启动的标识，变化说明注册中心重启过
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegisterRes) Reset() {
 	*x = RegisterRes{}
-	mi := &file_g_micor_discover_discover_proto_msgTypes[3]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -201,7 +380,7 @@ func (x *RegisterRes) String() string {
 func (*RegisterRes) ProtoMessage() {}
 
 func (x *RegisterRes) ProtoReflect() protoreflect.Message {
-	mi := &file_g_micor_discover_discover_proto_msgTypes[3]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -214,7 +393,308 @@ func (x *RegisterRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterRes.ProtoReflect.Descriptor instead.
 func (*RegisterRes) Descriptor() ([]byte, []int) {
-	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{3}
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RegisterRes) GetBootId() string {
+	if x != nil {
+		return x.BootId
+	}
+	return ""
+}
+
+// 服务注销请求
+type DeregisterReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Port          string                 `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"` // 对外地址，与注册时一致
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterReq) Reset() {
+	*x = DeregisterReq{}
+	mi := &file_g_micor_discover_discover_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterReq) ProtoMessage() {}
+
+func (x *DeregisterReq) ProtoReflect() protoreflect.Message {
+	mi := &file_g_micor_discover_discover_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterReq.ProtoReflect.Descriptor instead.
+func (*DeregisterReq) Descriptor() ([]byte, []int) {
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeregisterReq) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+func (x *DeregisterReq) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+// 服务注销响应
+type DeregisterRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterRes) Reset() {
+	*x = DeregisterRes{}
+	mi := &file_g_micor_discover_discover_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterRes) ProtoMessage() {}
+
+func (x *DeregisterRes) ProtoReflect() protoreflect.Message {
+	mi := &file_g_micor_discover_discover_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterRes.ProtoReflect.Descriptor instead.
+func (*DeregisterRes) Descriptor() ([]byte, []int) {
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{7}
+}
+
+// 心跳请求
+type HeartbeatReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Port          string                 `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"` // 对外地址，与注册时一致
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatReq) Reset() {
+	*x = HeartbeatReq{}
+	mi := &file_g_micor_discover_discover_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatReq) ProtoMessage() {}
+
+func (x *HeartbeatReq) ProtoReflect() protoreflect.Message {
+	mi := &file_g_micor_discover_discover_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatReq.ProtoReflect.Descriptor instead.
+func (*HeartbeatReq) Descriptor() ([]byte, []int) {
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HeartbeatReq) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+func (x *HeartbeatReq) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+// 心跳响应
+type HeartbeatRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BootId        string                 `protobuf:"bytes,1,opt,name=boot_id,json=bootId,proto3" json:"boot_id,omitempty"` // 注册中心本次启动的标识
+	Registered    bool                   `protobuf:"varint,2,opt,name=registered,proto3" json:"registered,omitempty"`      // 注册中心是否持有该节点的注册信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRes) Reset() {
+	*x = HeartbeatRes{}
+	mi := &file_g_micor_discover_discover_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRes) ProtoMessage() {}
+
+func (x *HeartbeatRes) ProtoReflect() protoreflect.Message {
+	mi := &file_g_micor_discover_discover_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRes.ProtoReflect.Descriptor instead.
+func (*HeartbeatRes) Descriptor() ([]byte, []int) {
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HeartbeatRes) GetBootId() string {
+	if x != nil {
+		return x.BootId
+	}
+	return ""
+}
+
+func (x *HeartbeatRes) GetRegistered() bool {
+	if x != nil {
+		return x.Registered
+	}
+	return false
+}
+
+// 订阅请求
+type WatchReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Server        string                 `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"` // 订阅的服务名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchReq) Reset() {
+	*x = WatchReq{}
+	mi := &file_g_micor_discover_discover_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchReq) ProtoMessage() {}
+
+func (x *WatchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_g_micor_discover_discover_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchReq.ProtoReflect.Descriptor instead.
+func (*WatchReq) Descriptor() ([]byte, []int) {
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchReq) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+// 订阅推送，每次推送服务当前的全部实例
+type WatchRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Server        string                 `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`       // 服务名
+	Instances     []*Instance            `protobuf:"bytes,2,rep,name=instances,proto3" json:"instances,omitempty"` // 服务实例
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRes) Reset() {
+	*x = WatchRes{}
+	mi := &file_g_micor_discover_discover_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRes) ProtoMessage() {}
+
+func (x *WatchRes) ProtoReflect() protoreflect.Message {
+	mi := &file_g_micor_discover_discover_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRes.ProtoReflect.Descriptor instead.
+func (*WatchRes) Descriptor() ([]byte, []int) {
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchRes) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *WatchRes) GetInstances() []*Instance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
 }
 
 // ping 请求
@@ -226,7 +706,7 @@ type PingReq struct {
 
 func (x *PingReq) Reset() {
 	*x = PingReq{}
-	mi := &file_g_micor_discover_discover_proto_msgTypes[4]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -238,7 +718,7 @@ func (x *PingReq) String() string {
 func (*PingReq) ProtoMessage() {}
 
 func (x *PingReq) ProtoReflect() protoreflect.Message {
-	mi := &file_g_micor_discover_discover_proto_msgTypes[4]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -251,7 +731,7 @@ func (x *PingReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PingReq.ProtoReflect.Descriptor instead.
 func (*PingReq) Descriptor() ([]byte, []int) {
-	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{4}
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{12}
 }
 
 // ping 响应
@@ -263,7 +743,7 @@ type PingRes struct {
 
 func (x *PingRes) Reset() {
 	*x = PingRes{}
-	mi := &file_g_micor_discover_discover_proto_msgTypes[5]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -275,7 +755,7 @@ func (x *PingRes) String() string {
 func (*PingRes) ProtoMessage() {}
 
 func (x *PingRes) ProtoReflect() protoreflect.Message {
-	mi := &file_g_micor_discover_discover_proto_msgTypes[5]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,13 +768,13 @@ func (x *PingRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PingRes.ProtoReflect.Descriptor instead.
 func (*PingRes) Descriptor() ([]byte, []int) {
-	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{5}
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{13}
 }
 
 // 服务新增通知请求
 type NotifyReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`     // 通知类型 1. register 服务上线 2. del 服务下线
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`     // 通知类型 1. register 服务上线 2. del 服务下线 3. status 健康状态变化
 	Server        string                 `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"` // 服务名
 	Addr          string                 `protobuf:"bytes,3,opt,name=addr,proto3" json:"addr,omitempty"`     // 服务地址
 	unknownFields protoimpl.UnknownFields
@@ -303,7 +783,7 @@ type NotifyReq struct {
 
 func (x *NotifyReq) Reset() {
 	*x = NotifyReq{}
-	mi := &file_g_micor_discover_discover_proto_msgTypes[6]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -315,7 +795,7 @@ func (x *NotifyReq) String() string {
 func (*NotifyReq) ProtoMessage() {}
 
 func (x *NotifyReq) ProtoReflect() protoreflect.Message {
-	mi := &file_g_micor_discover_discover_proto_msgTypes[6]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +808,7 @@ func (x *NotifyReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotifyReq.ProtoReflect.Descriptor instead.
 func (*NotifyReq) Descriptor() ([]byte, []int) {
-	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{6}
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *NotifyReq) GetType() string {
@@ -361,7 +841,7 @@ type NotifyRes struct {
 
 func (x *NotifyRes) Reset() {
 	*x = NotifyRes{}
-	mi := &file_g_micor_discover_discover_proto_msgTypes[7]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -373,7 +853,7 @@ func (x *NotifyRes) String() string {
 func (*NotifyRes) ProtoMessage() {}
 
 func (x *NotifyRes) ProtoReflect() protoreflect.Message {
-	mi := &file_g_micor_discover_discover_proto_msgTypes[7]
+	mi := &file_g_micor_discover_discover_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,34 +866,72 @@ func (x *NotifyRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotifyRes.ProtoReflect.Descriptor instead.
 func (*NotifyRes) Descriptor() ([]byte, []int) {
-	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{7}
+	return file_g_micor_discover_discover_proto_rawDescGZIP(), []int{15}
 }
 
 var File_g_micor_discover_discover_proto protoreflect.FileDescriptor
 
 const file_g_micor_discover_discover_proto_rawDesc = "" +
 	"\n" +
-	"\x1fg-micor/discover/discover.proto\x12\bdiscover\"1\n" +
+	"\x1fg-micor/discover/discover.proto\x12\bdiscover\"h\n" +
 	"\x03Req\x12\x12\n" +
 	"\x04port\x18\x01 \x01(\tR\x04port\x12\x16\n" +
-	"\x06server\x18\x02 \x01(\tR\x06server\"2\n" +
+	"\x06server\x18\x02 \x01(\tR\x06server\x12\x12\n" +
+	"\x04addr\x18\x03 \x01(\tR\x04addr\x12!\n" +
+	"\fno_subscribe\x18\x04 \x01(\bR\vnoSubscribe\"d\n" +
 	"\x04Resp\x12\x16\n" +
 	"\x06server\x18\x01 \x01(\tR\x06server\x12\x12\n" +
-	"\x04addr\x18\x02 \x03(\tR\x04addr\";\n" +
+	"\x04addr\x18\x02 \x03(\tR\x04addr\x120\n" +
+	"\tinstances\x18\x03 \x03(\v2\x12.discover.InstanceR\tinstances\"\x83\x01\n" +
+	"\bMetadata\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x12\n" +
+	"\x04zone\x18\x02 \x01(\tR\x04zone\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\x03R\tstartTime\"f\n" +
+	"\bInstance\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12.\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x12.discover.MetadataR\bmetadata\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\x7f\n" +
 	"\vRegisterReq\x12\x12\n" +
 	"\x04port\x18\x01 \x01(\tR\x04port\x12\x18\n" +
-	"\aservers\x18\x03 \x03(\tR\aservers\"\r\n" +
-	"\vRegisterRes\"\t\n" +
+	"\aservers\x18\x03 \x03(\tR\aservers\x12.\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x12.discover.MetadataR\bmetadata\x12\x12\n" +
+	"\x04addr\x18\x05 \x01(\tR\x04addr\"&\n" +
+	"\vRegisterRes\x12\x17\n" +
+	"\aboot_id\x18\x01 \x01(\tR\x06bootId\"7\n" +
+	"\rDeregisterReq\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\tR\x04port\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\"\x0f\n" +
+	"\rDeregisterRes\"6\n" +
+	"\fHeartbeatReq\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\tR\x04port\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\"G\n" +
+	"\fHeartbeatRes\x12\x17\n" +
+	"\aboot_id\x18\x01 \x01(\tR\x06bootId\x12\x1e\n" +
+	"\n" +
+	"registered\x18\x02 \x01(\bR\n" +
+	"registered\"\"\n" +
+	"\bWatchReq\x12\x16\n" +
+	"\x06server\x18\x01 \x01(\tR\x06server\"T\n" +
+	"\bWatchRes\x12\x16\n" +
+	"\x06server\x18\x01 \x01(\tR\x06server\x120\n" +
+	"\tinstances\x18\x02 \x03(\v2\x12.discover.InstanceR\tinstances\"\t\n" +
 	"\aPingReq\"\t\n" +
 	"\aPingRes\"K\n" +
 	"\tNotifyReq\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
 	"\x06server\x18\x02 \x01(\tR\x06server\x12\x12\n" +
 	"\x04addr\x18\x03 \x01(\tR\x04addr\"\v\n" +
-	"\tNotifyRes2s\n" +
+	"\tNotifyRes2\xa9\x02\n" +
 	"\bRegister\x12:\n" +
 	"\bRegister\x12\x15.discover.RegisterReq\x1a\x15.discover.RegisterRes\"\x00\x12+\n" +
-	"\bDiscover\x12\r.discover.Req\x1a\x0e.discover.Resp\"\x002\x7f\n" +
+	"\bDiscover\x12\r.discover.Req\x1a\x0e.discover.Resp\"\x00\x12@\n" +
+	"\n" +
+	"Deregister\x12\x17.discover.DeregisterReq\x1a\x17.discover.DeregisterRes\"\x00\x12=\n" +
+	"\tHeartbeat\x12\x16.discover.HeartbeatReq\x1a\x16.discover.HeartbeatRes\"\x00\x123\n" +
+	"\x05Watch\x12\x12.discover.WatchReq\x1a\x12.discover.WatchRes\"\x000\x012\x7f\n" +
 	"\x06Client\x12.\n" +
 	"\x04Ping\x12\x11.discover.PingReq\x1a\x11.discover.PingRes\"\x00\x12E\n" +
 	"\x17SubscribeServerRegister\x12\x13.discover.NotifyReq\x1a\x13.discover.NotifyRes\"\x00B'Z%github.com/Gong-Yang/g-micor/discoverb\x06proto3"
@@ -430,31 +948,49 @@ func file_g_micor_discover_discover_proto_rawDescGZIP() []byte {
 	return file_g_micor_discover_discover_proto_rawDescData
 }
 
-var file_g_micor_discover_discover_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_g_micor_discover_discover_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_g_micor_discover_discover_proto_goTypes = []any{
-	(*Req)(nil),         // 0: discover.Req
-	(*Resp)(nil),        // 1: discover.Resp
-	(*RegisterReq)(nil), // 2: discover.RegisterReq
-	(*RegisterRes)(nil), // 3: discover.RegisterRes
-	(*PingReq)(nil),     // 4: discover.PingReq
-	(*PingRes)(nil),     // 5: discover.PingRes
-	(*NotifyReq)(nil),   // 6: discover.NotifyReq
-	(*NotifyRes)(nil),   // 7: discover.NotifyRes
+	(*Req)(nil),           // 0: discover.Req
+	(*Resp)(nil),          // 1: discover.Resp
+	(*Metadata)(nil),      // 2: discover.Metadata
+	(*Instance)(nil),      // 3: discover.Instance
+	(*RegisterReq)(nil),   // 4: discover.RegisterReq
+	(*RegisterRes)(nil),   // 5: discover.RegisterRes
+	(*DeregisterReq)(nil), // 6: discover.DeregisterReq
+	(*DeregisterRes)(nil), // 7: discover.DeregisterRes
+	(*HeartbeatReq)(nil),  // 8: discover.HeartbeatReq
+	(*HeartbeatRes)(nil),  // 9: discover.HeartbeatRes
+	(*WatchReq)(nil),      // 10: discover.WatchReq
+	(*WatchRes)(nil),      // 11: discover.WatchRes
+	(*PingReq)(nil),       // 12: discover.PingReq
+	(*PingRes)(nil),       // 13: discover.PingRes
+	(*NotifyReq)(nil),     // 14: discover.NotifyReq
+	(*NotifyRes)(nil),     // 15: discover.NotifyRes
 }
 var file_g_micor_discover_discover_proto_depIdxs = []int32{
-	2, // 0: discover.Register.Register:input_type -> discover.RegisterReq
-	0, // 1: discover.Register.Discover:input_type -> discover.Req
-	4, // 2: discover.Client.Ping:input_type -> discover.PingReq
-	6, // 3: discover.Client.SubscribeServerRegister:input_type -> discover.NotifyReq
-	3, // 4: discover.Register.Register:output_type -> discover.RegisterRes
-	1, // 5: discover.Register.Discover:output_type -> discover.Resp
-	5, // 6: discover.Client.Ping:output_type -> discover.PingRes
-	7, // 7: discover.Client.SubscribeServerRegister:output_type -> discover.NotifyRes
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3,  // 0: discover.Resp.instances:type_name -> discover.Instance
+	2,  // 1: discover.Instance.metadata:type_name -> discover.Metadata
+	2,  // 2: discover.RegisterReq.metadata:type_name -> discover.Metadata
+	3,  // 3: discover.WatchRes.instances:type_name -> discover.Instance
+	4,  // 4: discover.Register.Register:input_type -> discover.RegisterReq
+	0,  // 5: discover.Register.Discover:input_type -> discover.Req
+	6,  // 6: discover.Register.Deregister:input_type -> discover.DeregisterReq
+	8,  // 7: discover.Register.Heartbeat:input_type -> discover.HeartbeatReq
+	10, // 8: discover.Register.Watch:input_type -> discover.WatchReq
+	12, // 9: discover.Client.Ping:input_type -> discover.PingReq
+	14, // 10: discover.Client.SubscribeServerRegister:input_type -> discover.NotifyReq
+	5,  // 11: discover.Register.Register:output_type -> discover.RegisterRes
+	1,  // 12: discover.Register.Discover:output_type -> discover.Resp
+	7,  // 13: discover.Register.Deregister:output_type -> discover.DeregisterRes
+	9,  // 14: discover.Register.Heartbeat:output_type -> discover.HeartbeatRes
+	11, // 15: discover.Register.Watch:output_type -> discover.WatchRes
+	13, // 16: discover.Client.Ping:output_type -> discover.PingRes
+	15, // 17: discover.Client.SubscribeServerRegister:output_type -> discover.NotifyRes
+	11, // [11:18] is the sub-list for method output_type
+	4,  // [4:11] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_g_micor_discover_discover_proto_init() }
@@ -468,7 +1004,7 @@ func file_g_micor_discover_discover_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_g_micor_discover_discover_proto_rawDesc), len(file_g_micor_discover_discover_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
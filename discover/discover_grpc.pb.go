@@ -19,8 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Register_Register_FullMethodName = "/discover.Register/Register"
-	Register_Discover_FullMethodName = "/discover.Register/Discover"
+	Register_Register_FullMethodName   = "/discover.Register/Register"
+	Register_Discover_FullMethodName   = "/discover.Register/Discover"
+	Register_Deregister_FullMethodName = "/discover.Register/Deregister"
+	Register_Heartbeat_FullMethodName  = "/discover.Register/Heartbeat"
+	Register_Watch_FullMethodName      = "/discover.Register/Watch"
 )
 
 // RegisterClient is the client API for Register service.
@@ -29,6 +32,10 @@ const (
 type RegisterClient interface {
 	Register(ctx context.Context, in *RegisterReq, opts ...grpc.CallOption) (*RegisterRes, error)
 	Discover(ctx context.Context, in *Req, opts ...grpc.CallOption) (*Resp, error)
+	Deregister(ctx context.Context, in *DeregisterReq, opts ...grpc.CallOption) (*DeregisterRes, error)
+	Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grpc.CallOption) (*HeartbeatRes, error)
+	// Watch 订阅服务变化，由客户端保持连接，服务端推送实例快照，无需注册中心反向连接客户端
+	Watch(ctx context.Context, in *WatchReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchRes], error)
 }
 
 type registerClient struct {
@@ -59,12 +66,55 @@ func (c *registerClient) Discover(ctx context.Context, in *Req, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *registerClient) Deregister(ctx context.Context, in *DeregisterReq, opts ...grpc.CallOption) (*DeregisterRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeregisterRes)
+	err := c.cc.Invoke(ctx, Register_Deregister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registerClient) Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grpc.CallOption) (*HeartbeatRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatRes)
+	err := c.cc.Invoke(ctx, Register_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registerClient) Watch(ctx context.Context, in *WatchReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchRes], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Register_ServiceDesc.Streams[0], Register_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchReq, WatchRes]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Register_WatchClient = grpc.ServerStreamingClient[WatchRes]
+
 // RegisterServer is the server API for Register service.
 // All implementations must embed UnimplementedRegisterServer
 // for forward compatibility.
 type RegisterServer interface {
 	Register(context.Context, *RegisterReq) (*RegisterRes, error)
 	Discover(context.Context, *Req) (*Resp, error)
+	Deregister(context.Context, *DeregisterReq) (*DeregisterRes, error)
+	Heartbeat(context.Context, *HeartbeatReq) (*HeartbeatRes, error)
+	// Watch 订阅服务变化，由客户端保持连接，服务端推送实例快照，无需注册中心反向连接客户端
+	Watch(*WatchReq, grpc.ServerStreamingServer[WatchRes]) error
 	mustEmbedUnimplementedRegisterServer()
 }
 
@@ -81,6 +131,15 @@ func (UnimplementedRegisterServer) Register(context.Context, *RegisterReq) (*Reg
 func (UnimplementedRegisterServer) Discover(context.Context, *Req) (*Resp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Discover not implemented")
 }
+func (UnimplementedRegisterServer) Deregister(context.Context, *DeregisterReq) (*DeregisterRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deregister not implemented")
+}
+func (UnimplementedRegisterServer) Heartbeat(context.Context, *HeartbeatReq) (*HeartbeatRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedRegisterServer) Watch(*WatchReq, grpc.ServerStreamingServer[WatchRes]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedRegisterServer) mustEmbedUnimplementedRegisterServer() {}
 func (UnimplementedRegisterServer) testEmbeddedByValue()                  {}
 
@@ -138,6 +197,53 @@ func _Register_Discover_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Register_Deregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegisterServer).Deregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Register_Deregister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegisterServer).Deregister(ctx, req.(*DeregisterReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Register_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegisterServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Register_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegisterServer).Heartbeat(ctx, req.(*HeartbeatReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Register_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegisterServer).Watch(m, &grpc.GenericServerStream[WatchReq, WatchRes]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Register_WatchServer = grpc.ServerStreamingServer[WatchRes]
+
 // Register_ServiceDesc is the grpc.ServiceDesc for Register service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,8 +259,22 @@ var Register_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Discover",
 			Handler:    _Register_Discover_Handler,
 		},
+		{
+			MethodName: "Deregister",
+			Handler:    _Register_Deregister_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Register_Heartbeat_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Register_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "g-micor/discover/discover.proto",
 }
 
@@ -0,0 +1,191 @@
+package discover
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// 实例健康状态，随实例推送给订阅者，解析器只使用 healthy 和 suspect 的实例
+const (
+	StatusHealthy  = "healthy"  // 检查正常
+	StatusSuspect  = "suspect"  // 检查失败但未达到阈值，仍可调用
+	StatusDraining = "draining" // 实例报告 NOT_SERVING，通常是正在停机，不再分配新请求
+	StatusDown     = "down"     // 连续失败达到阈值，被剔除出调用列表
+)
+
+// HealthCheckConfig 健康检查配置
+type HealthCheckConfig struct {
+	Interval         time.Duration // 检查间隔
+	Timeout          time.Duration // 单次检查超时
+	FailureThreshold int           // 连续失败多少次标记为 down
+	SuccessThreshold int           // down/draining 后连续成功多少次恢复为 healthy
+	EvictAfter       time.Duration // down 持续多久后移除实例
+}
+
+var defaultHealthCheck = HealthCheckConfig{
+	Interval:         10 * time.Second,
+	Timeout:          3 * time.Second,
+	FailureThreshold: 3,
+	SuccessThreshold: 2,
+	EvictAfter:       time.Minute,
+}
+
+// WithHealthCheck 设置健康检查参数，未设置的字段使用默认值
+func WithHealthCheck(conf HealthCheckConfig) Option {
+	return func(s *Service) {
+		if conf.Interval > 0 {
+			s.healthCheck.Interval = conf.Interval
+		}
+		if conf.Timeout > 0 {
+			s.healthCheck.Timeout = conf.Timeout
+		}
+		if conf.FailureThreshold > 0 {
+			s.healthCheck.FailureThreshold = conf.FailureThreshold
+		}
+		if conf.SuccessThreshold > 0 {
+			s.healthCheck.SuccessThreshold = conf.SuccessThreshold
+		}
+		if conf.EvictAfter > 0 {
+			s.healthCheck.EvictAfter = conf.EvictAfter
+		}
+	}
+}
+
+// nodeConn 与节点的RPC连接
+type nodeConn struct {
+	conn   *grpc.ClientConn
+	client ClientClient
+	health healthpb.HealthClient
+}
+
+// instanceHealth 实例的健康状态
+type instanceHealth struct {
+	Status    string
+	LastPing  time.Time
+	Error     string
+	failures  int       // 连续失败次数
+	successes int       // 连续成功次数
+	downSince time.Time // 标记为 down 的时间
+}
+
+// startHealthCheck 启动健康检查协程
+// 定期并发检查所有注册服务的健康状态
+func (s *Service) startHealthCheck() {
+	slog.Info("启动健康检查服务", "interval", s.healthCheck.Interval, "timeout", s.healthCheck.Timeout)
+	ticker := time.NewTicker(s.healthCheck.Interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.performHealthCheck()
+	}
+}
+
+// performHealthCheck 并发检查所有实例，全部完成后才开始下一轮
+func (s *Service) performHealthCheck() {
+	s.lock.RLock()
+	nodes := make(map[string]*nodeConn, len(s.addrStore))
+	for addr, node := range s.addrStore {
+		nodes[addr] = node
+	}
+	s.lock.RUnlock()
+
+	var wg sync.WaitGroup
+	for addr, node := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			serving, err := s.check(node)
+			s.recordCheck(addr, serving, err)
+		}()
+	}
+	wg.Wait()
+}
+
+// check 使用标准的 grpc.health.v1 协议检查实例
+// 实例未实现健康检查服务时退回 Client.Ping
+func (s *Service) check(node *nodeConn) (serving bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.healthCheck.Timeout)
+	defer cancel()
+	res, err := node.health.Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		_, err = node.client.Ping(ctx, &PingReq{})
+		return err == nil, err
+	}
+	if err != nil {
+		return false, err
+	}
+	return res.Status == healthpb.HealthCheckResponse_SERVING, nil
+}
+
+// recordCheck 根据检查结果更新实例状态，状态变化时通知订阅者
+func (s *Service) recordCheck(addr string, serving bool, err error) {
+	s.lock.Lock()
+	h := s.health[addr]
+	if h == nil {
+		// 检查期间实例已被移除
+		s.lock.Unlock()
+		return
+	}
+	conf := s.healthCheck
+	now := time.Now()
+	old := h.Status
+	h.LastPing = now
+	h.Error = ""
+
+	switch {
+	case err != nil:
+		h.Error = err.Error()
+		h.successes = 0
+		h.failures++
+		if h.failures >= conf.FailureThreshold {
+			if h.Status != StatusDown {
+				h.downSince = now
+			}
+			h.Status = StatusDown
+		} else if h.Status == StatusHealthy {
+			h.Status = StatusSuspect
+		}
+	case !serving:
+		h.failures = 0
+		h.successes = 0
+		h.Status = StatusDraining
+	default:
+		h.failures = 0
+		h.successes++
+		if h.Status == StatusSuspect || h.successes >= conf.SuccessThreshold {
+			h.Status = StatusHealthy
+		}
+	}
+	current, errMsg := h.Status, h.Error
+	evict := current == StatusDown && now.Sub(h.downSince) >= conf.EvictAfter
+	names := s.sAddrToSNames[addr]
+	s.lock.Unlock()
+
+	if evict {
+		slog.Warn("实例持续不可用，移除实例", "addr", addr, "error", errMsg)
+		s.removeInstance(addr)
+		return
+	}
+	if current == old {
+		return
+	}
+	slog.Warn("实例健康状态变化", "addr", addr, "from", old, "to", current, "error", errMsg)
+	for _, name := range names {
+		s.notifySubscribers(&NotifyReq{Type: "status", Server: name, Addr: addr})
+	}
+}
+
+// available 实例是否可以分配请求
+func available(instance *Instance) bool {
+	switch instance.GetStatus() {
+	case StatusDraining, StatusDown:
+		return false
+	}
+	return true
+}
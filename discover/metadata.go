@@ -0,0 +1,136 @@
+package discover
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultWeight 未设置权重的实例按该权重处理
+const DefaultWeight = 100
+
+// 解析目标中的过滤参数，如 g-micor:///user?version=v2&zone=sh&tag=canary
+const (
+	queryVersion = "version"
+	queryZone    = "zone"
+	queryTag     = "tag"
+)
+
+// GrpcOption 调用方对服务实例的选择条件及负载均衡策略
+type GrpcOption func(o *grpcOptions)
+
+type grpcOptions struct {
+	query    url.Values // 附加到解析目标上的过滤参数
+	balancer string     // 负载均衡策略
+}
+
+// WithVersion 只调用指定版本的实例，用于灰度发布
+func WithVersion(version string) GrpcOption {
+	return func(o *grpcOptions) {
+		o.query.Set(queryVersion, version)
+	}
+}
+
+// WithZone 优先调用同区域的实例，同区域没有可用实例时调用其它区域
+func WithZone(zone string) GrpcOption {
+	return func(o *grpcOptions) {
+		o.query.Set(queryZone, zone)
+	}
+}
+
+// WithTags 只调用包含全部标签的实例
+func WithTags(tags ...string) GrpcOption {
+	return func(o *grpcOptions) {
+		for _, tag := range tags {
+			o.query.Add(queryTag, tag)
+		}
+	}
+}
+
+// GetWeightOrDefault 实例权重，未设置时返回 DefaultWeight
+func (m *Metadata) GetWeightOrDefault() int32 {
+	if w := m.GetWeight(); w > 0 {
+		return w
+	}
+	return DefaultWeight
+}
+
+type metadataKey struct{}
+
+// metadataAttr 包装元数据，实现 Equal 以便元数据不变时 grpc 复用已有连接
+type metadataAttr struct {
+	*Metadata
+}
+
+func (m metadataAttr) Equal(o any) bool {
+	other, ok := o.(metadataAttr)
+	return ok && proto.Equal(m.Metadata, other.Metadata)
+}
+
+// MetadataFromAddress 从解析出的地址中取出实例元数据，没有时返回 nil
+func MetadataFromAddress(addr resolver.Address) *Metadata {
+	if addr.Attributes == nil {
+		return nil
+	}
+	attr, _ := addr.Attributes.Value(metadataKey{}).(metadataAttr)
+	return attr.Metadata
+}
+
+// instanceFilter 按解析目标中的参数筛选实例
+type instanceFilter struct {
+	version string
+	zone    string
+	tags    []string
+}
+
+func newInstanceFilter(target resolver.Target) instanceFilter {
+	q := target.URL.Query()
+	return instanceFilter{
+		version: q.Get(queryVersion),
+		zone:    q.Get(queryZone),
+		tags:    q[queryTag],
+	}
+}
+
+func (f instanceFilter) filter(instances []*Instance) []*Instance {
+	matched := make([]*Instance, 0, len(instances))
+	for _, instance := range instances {
+		if !available(instance) {
+			continue
+		}
+		meta := instance.GetMetadata()
+		if f.version != "" && meta.GetVersion() != f.version {
+			continue
+		}
+		if !containsAll(meta.GetTags(), f.tags) {
+			continue
+		}
+		matched = append(matched, instance)
+	}
+	if f.zone == "" {
+		return matched
+	}
+	// 同区域优先
+	local := make([]*Instance, 0, len(matched))
+	for _, instance := range matched {
+		if strings.EqualFold(instance.GetMetadata().GetZone(), f.zone) {
+			local = append(local, instance)
+		}
+	}
+	if len(local) > 0 {
+		return local
+	}
+	return matched
+}
+
+func containsAll(tags, required []string) bool {
+	for _, tag := range required {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,314 @@
+package discover
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	redisInstanceTTL    = 15 * time.Second // 实例key过期时间
+	redisKeepAlive      = 5 * time.Second  // 续期间隔
+	redisIndexPrefix    = "discover:service:"
+	redisInstancePrefix = "discover:instance:"
+	redisNotifyPrefix   = "discover:notify:"
+)
+
+// redisRegistry 基于Redis的实现，无需部署 discover.Run
+//
+// discover:service:<服务名>          ZSET 成员为实例地址，分值为过期时间戳，用于快速查询
+// discover:instance:<服务名>:<地址>  实例key，值为实例的JSON(含元数据)，带TTL，由注册方定时续期
+// discover:notify:<服务名>           注册/注销时发布，触发订阅者刷新
+//
+// 实例异常退出未注销时，实例key过期产生的 keyspace 事件同样会触发刷新；
+// Redis 需配置 notify-keyspace-events Ex，未开启时订阅者按TTL周期兜底刷新
+type redisRegistry struct {
+	client *redis.Client
+	addr   string   // 本节点对外地址
+	names  []string // 本节点提供的服务
+	value  string   // 本节点实例信息的JSON
+	stop   context.CancelFunc
+	closed chan struct{}
+	once   sync.Once // 保证 closed 只关闭一次
+
+	lock     sync.Mutex
+	watchers map[string]map[*watcher]struct{}
+	sub      *redis.PubSub
+
+	setKeyspaceEvents bool // 未开启过期事件通知时修改 Redis 配置
+}
+
+// RedisOption 基于Redis的 Registry 的可选配置
+type RedisOption func(r *redisRegistry)
+
+// WithKeyspaceEvents 未开启过期事件通知时，通过 CONFIG SET 修改 Redis 的 notify-keyspace-events
+// 该配置对整个 Redis 生效，共用的 Redis 上应由运维统一配置，不要开启此选项
+func WithKeyspaceEvents() RedisOption {
+	return func(r *redisRegistry) {
+		r.setKeyspaceEvents = true
+	}
+}
+
+// NewRedisRegistry 创建基于Redis的 Registry
+func NewRedisRegistry(client *redis.Client, opts ...RedisOption) (Registry, error) {
+	r := &redisRegistry{
+		client:   client,
+		closed:   make(chan struct{}),
+		watchers: make(map[string]map[*watcher]struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	ctx := context.Background()
+	r.checkKeyspaceEvents(ctx)
+
+	// 订阅注册/注销通知与过期事件
+	expired := fmt.Sprintf("__keyevent@%d__:expired", client.Options().DB)
+	r.sub = client.PSubscribe(ctx, redisNotifyPrefix+"*", expired)
+	if _, err := r.sub.Receive(ctx); err != nil {
+		return nil, fmt.Errorf("redis subscribe failed: %w", err)
+	}
+	go r.listen(expired)
+	go r.resyncLoop()
+	return r, nil
+}
+
+// checkKeyspaceEvents 检查过期事件通知是否开启，未开启时默认只告警，
+// 配置了 WithKeyspaceEvents 时尝试开启，失败时仅依赖周期刷新
+func (r *redisRegistry) checkKeyspaceEvents(ctx context.Context) {
+	conf, err := r.client.ConfigGet(ctx, "notify-keyspace-events").Result()
+	if err != nil {
+		slog.Warn("redis registry get notify-keyspace-events failed", "error", err)
+		return
+	}
+	current := conf["notify-keyspace-events"]
+	if strings.Contains(current, "E") && (strings.Contains(current, "x") || strings.Contains(current, "A")) {
+		return
+	}
+	if !r.setKeyspaceEvents {
+		slog.Warn("redis registry keyspace events disabled, fallback to periodic resync; configure notify-keyspace-events Ex on redis",
+			"current", current)
+		return
+	}
+	if err = r.client.ConfigSet(ctx, "notify-keyspace-events", current+"Ex").Err(); err != nil {
+		slog.Warn("redis registry enable keyspace events failed, fallback to periodic resync", "error", err)
+	}
+}
+
+func (r *redisRegistry) Register(ctx context.Context, req *RegisterReq) error {
+	r.addr = req.Addr
+	if r.addr == "" {
+		r.addr = joinPort(localIP(), req.Port)
+	}
+	r.names = req.Servers
+	value, err := protojson.Marshal(&Instance{Addr: r.addr, Metadata: req.Metadata})
+	if err != nil {
+		return err
+	}
+	r.value = string(value)
+	if err := r.keepAlive(ctx); err != nil {
+		slog.Error("register error", "error", err)
+		return err
+	}
+	for _, name := range r.names {
+		r.client.Publish(ctx, redisNotifyPrefix+name, "register")
+	}
+
+	aliveCtx, cancel := context.WithCancel(context.Background())
+	r.stop = cancel
+	go func() {
+		ticker := time.NewTicker(redisKeepAlive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := r.keepAlive(aliveCtx)
+				if aliveCtx.Err() != nil {
+					// 已注销
+					return
+				}
+				if err != nil {
+					slog.Error("redis registry keepalive error", "error", err)
+				}
+				setRegistered(err)
+			case <-aliveCtx.Done():
+				return
+			}
+		}
+	}()
+	slog.Info("redis registry register success", "addr", r.addr, "servers", r.names)
+	return nil
+}
+
+// keepAlive 写入/续期本节点的实例key和索引
+func (r *redisRegistry) keepAlive(ctx context.Context) error {
+	now := time.Now()
+	expireAt := float64(now.Add(redisInstanceTTL).Unix())
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, name := range r.names {
+			pipe.Set(ctx, redisInstanceKey(name, r.addr), r.value, redisInstanceTTL)
+			pipe.ZAdd(ctx, redisIndexPrefix+name, redis.Z{Score: expireAt, Member: r.addr})
+			// 顺带清理过期的实例
+			pipe.ZRemRangeByScore(ctx, redisIndexPrefix+name, "-inf", strconv.FormatInt(now.Unix(), 10))
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *redisRegistry) Deregister(ctx context.Context) error {
+	if r.stop != nil {
+		r.stop()
+	}
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, name := range r.names {
+			pipe.Del(ctx, redisInstanceKey(name, r.addr))
+			pipe.ZRem(ctx, redisIndexPrefix+name, r.addr)
+			pipe.Publish(ctx, redisNotifyPrefix+name, "del")
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *redisRegistry) Discover(ctx context.Context, server string) ([]*Instance, error) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	members, err := r.client.ZRangeByScore(ctx, redisIndexPrefix+server, &redis.ZRangeBy{Min: now, Max: "+inf"}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	// 以实例key为准，过滤已过期但索引尚未清理的实例
+	keys := make([]string, len(members))
+	for i, member := range members {
+		keys[i] = redisInstanceKey(server, member)
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]*Instance, 0, len(values))
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		instance := &Instance{}
+		if err = protojson.Unmarshal([]byte(data), instance); err != nil {
+			slog.Warn("redis registry invalid instance", "key", keys[i], "error", err)
+			continue
+		}
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+func (r *redisRegistry) Watch(ctx context.Context, server string, notify func(instances []*Instance)) error {
+	w := &watcher{notify: notify}
+	r.lock.Lock()
+	if r.watchers[server] == nil {
+		r.watchers[server] = make(map[*watcher]struct{})
+	}
+	r.watchers[server][w] = struct{}{}
+	r.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		r.lock.Lock()
+		delete(r.watchers[server], w)
+		if len(r.watchers[server]) == 0 {
+			delete(r.watchers, server)
+		}
+		r.lock.Unlock()
+	}()
+
+	// 首次查询失败时返回错误，调用方取消 ctx 后订阅随之移除
+	instances, err := r.Discover(ctx, server)
+	if err != nil {
+		slog.Error("discover error", "server", server, "error", err)
+		return err
+	}
+	notify(instances)
+	return nil
+}
+
+// listen 处理注册/注销通知和实例过期事件
+func (r *redisRegistry) listen(expired string) {
+	for msg := range r.sub.Channel() {
+		var server string
+		if msg.Channel == expired {
+			key, ok := strings.CutPrefix(msg.Payload, redisInstancePrefix)
+			if !ok {
+				continue
+			}
+			server, _, _ = strings.Cut(key, ":")
+		} else {
+			server = strings.TrimPrefix(msg.Channel, redisNotifyPrefix)
+		}
+		r.refresh(server)
+	}
+}
+
+// resyncLoop 周期刷新所有订阅的服务，兜底丢失的通知
+func (r *redisRegistry) resyncLoop() {
+	ticker := time.NewTicker(redisInstanceTTL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-r.closed:
+			return
+		}
+		r.lock.Lock()
+		servers := make([]string, 0, len(r.watchers))
+		for server := range r.watchers {
+			servers = append(servers, server)
+		}
+		r.lock.Unlock()
+		for _, server := range servers {
+			r.refresh(server)
+		}
+	}
+}
+
+// refresh 重新查询服务地址并通知订阅者
+func (r *redisRegistry) refresh(server string) {
+	r.lock.Lock()
+	ws := make([]*watcher, 0, len(r.watchers[server]))
+	for w := range r.watchers[server] {
+		ws = append(ws, w)
+	}
+	r.lock.Unlock()
+	if len(ws) == 0 {
+		return
+	}
+	instances, err := r.Discover(context.Background(), server)
+	if err != nil {
+		slog.Error("discover error", "server", server, "error", err)
+		return
+	}
+	for _, w := range ws {
+		w.notify(instances)
+	}
+}
+
+func (r *redisRegistry) Close() error {
+	if r.stop != nil {
+		r.stop()
+	}
+	r.once.Do(func() { close(r.closed) })
+	return r.sub.Close()
+}
+
+func redisInstanceKey(server, addr string) string {
+	return redisInstancePrefix + server + ":" + addr
+}
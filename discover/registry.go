@@ -0,0 +1,134 @@
+package discover
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	ErrRegistryNotInit = errors.New("ErrRegistryNotInit")
+	ErrNotRegistered   = errors.New("ErrNotRegistered") // 本节点未注册或已注销
+)
+
+// 注册中心类型
+const (
+	RegistryCenter = "center" // 自建注册中心 discover.Run
+	RegistryRedis  = "redis"  // 基于Redis，无需部署注册中心
+)
+
+// Registry 服务注册与发现的后端
+// 解析器只依赖该接口，可以在自建注册中心与Redis之间切换
+type Registry interface {
+	// Register 注册本节点提供的服务
+	Register(ctx context.Context, req *RegisterReq) error
+	// Deregister 注销本节点
+	Deregister(ctx context.Context) error
+	// Discover 获取服务当前的实例列表
+	Discover(ctx context.Context, server string) ([]*Instance, error)
+	// Watch 订阅服务实例变化，实例列表变化时回调 notify，ctx 结束后取消订阅
+	Watch(ctx context.Context, server string, notify func(instances []*Instance)) error
+	// Close 释放连接等资源
+	Close() error
+}
+
+var registry Registry
+
+// clientCreds 连接注册中心和其它服务使用的传输凭证
+var clientCreds = insecure.NewCredentials()
+
+// SetClientCredentials 设置连接注册中心和其它服务使用的传输凭证，需在创建 Registry 和 Grpc 之前调用
+func SetClientCredentials(creds credentials.TransportCredentials) {
+	clientCreds = creds
+}
+
+// SetRegistry 设置全局使用的注册中心后端，需在 Grpc 之前调用
+func SetRegistry(r Registry) {
+	registry = r
+}
+
+// Register 向注册中心注册本节点
+func Register(ctx context.Context, req *RegisterReq) error {
+	if registry == nil {
+		return ErrRegistryNotInit
+	}
+	err := registry.Register(ctx, req)
+	setRegistered(err)
+	return err
+}
+
+// Deregister 向注册中心注销本节点，订阅者会立即收到下线通知
+func Deregister(ctx context.Context) error {
+	if registry == nil {
+		return nil
+	}
+	setRegistered(ErrNotRegistered)
+	return registry.Deregister(ctx)
+}
+
+// registerState 本节点的注册状态，注册后由心跳或续期更新
+var registerState = struct {
+	sync.Mutex
+	err error
+}{err: ErrNotRegistered}
+
+func setRegistered(err error) {
+	registerState.Lock()
+	registerState.err = err
+	registerState.Unlock()
+}
+
+// Registered 本节点的注册状态，未注册、已注销或最近一次心跳、续期失败时返回错误
+func Registered() error {
+	registerState.Lock()
+	defer registerState.Unlock()
+	return registerState.err
+}
+
+// Close 断开与注册中心的连接
+func Close() error {
+	if registry == nil {
+		return nil
+	}
+	return registry.Close()
+}
+
+// RegisterCenter 连接自建注册中心并注册本节点
+func RegisterCenter(addr string, req *RegisterReq) {
+	r, err := NewCenterRegistry(addr)
+	if err != nil {
+		panic(err)
+	}
+	SetRegistry(r)
+	if err = Register(context.Background(), req); err != nil {
+		panic(err)
+	}
+}
+
+// localIP 获取本机第一个非回环的IPv4地址
+func localIP() string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "127.0.0.1"
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() {
+			continue
+		}
+		if ip := ipNet.IP.To4(); ip != nil {
+			return ip.String()
+		}
+	}
+	return "127.0.0.1"
+}
+
+// joinPort 将 ":8081" 形式的端口与主机拼接为地址
+func joinPort(host, port string) string {
+	return net.JoinHostPort(host, strings.TrimPrefix(port, ":"))
+}
@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"log/slog"
 	"net"
+	"slices"
 	"strings"
 	"sync"
-	"time"
+	"sync/atomic"
 
+	"github.com/Gong-Yang/g-micor/util/random"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/peer"
 )
 
@@ -20,10 +23,43 @@ var (
 	ErrServerNotFind = errors.New("ErrServerNotFind")
 )
 
+// Option 注册中心配置项
+type Option func(s *Service)
+
+// WithStore 开启状态持久化，启动时从 store 恢复，运行中定期保存
+func WithStore(store Store) Option {
+	return func(s *Service) {
+		s.store = store
+	}
+}
+
+// WithAdminAddr 开启管理后台，在 addr 上提供HTTP接口和页面，用于查看状态、剔除实例
+// addr 只指定端口(如 ":8081")时只监听本机回环地址，需要远程访问时显式指定监听的IP并配合 WithAdminToken
+func WithAdminAddr(addr string) Option {
+	return func(s *Service) {
+		s.adminAddr = addr
+	}
+}
+
+// WithAdminToken 设置管理后台的令牌，查看状态及剔除实例、强制通知等写操作需在请求头 X-Admin-Token 中携带
+func WithAdminToken(token string) Option {
+	return func(s *Service) {
+		s.adminToken = token
+	}
+}
+
+// WithCredentials 设置传输凭证，server 用于注册中心自身的RPC服务，client 用于连接各节点
+func WithCredentials(server, client credentials.TransportCredentials) Option {
+	return func(s *Service) {
+		s.serverCreds = server
+		s.clientCreds = client
+	}
+}
+
 // Run 启动服务发现服务器
 // addr: 监听地址，格式为 "host:port"
 // 返回错误信息，如果启动失败
-func Run(addr string) error {
+func Run(addr string, opts ...Option) error {
 	slog.Info("启动服务发现服务器", "addr", addr)
 
 	// 监听TCP连接
@@ -33,7 +69,6 @@ func Run(addr string) error {
 		return err
 	}
 	slog.Info("服务发现服务器监听成功", "addr", addr)
-	s := grpc.NewServer()
 
 	// 创建服务实例
 	service := &Service{
@@ -41,7 +76,28 @@ func Run(addr string) error {
 		sNameToSAddr:  make(map[string][]string),
 		sAddrToSNames: make(map[string][]string),
 		sNameToDAddr:  make(map[string][]string),
-		addrStore:     make(map[string]ClientClient),
+		addrStore:     make(map[string]*nodeConn),
+		sAddrToMeta:   make(map[string]*Metadata),
+		health:        make(map[string]*instanceHealth),
+		streams:       make(map[string]map[*streamWatcher]struct{}),
+		bootID:        random.ShortUUID(),
+		serverCreds:   insecure.NewCredentials(),
+		clientCreds:   insecure.NewCredentials(),
+		healthCheck:   defaultHealthCheck,
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	s := grpc.NewServer(grpc.Creds(service.serverCreds))
+	if service.store != nil {
+		if err = service.restore(context.Background()); err != nil {
+			slog.Error("恢复注册中心快照失败", "err", err)
+			return err
+		}
+		go service.startPersist()
+	}
+	if service.adminAddr != "" {
+		go service.startAdmin(service.adminAddr)
 	}
 
 	// 注册RPC服务
@@ -60,6 +116,7 @@ func Run(addr string) error {
 
 	// 启动心跳检查
 	go service.startHealthCheck()
+	slog.Info("RegisterCenter boot", "bootID", service.bootID)
 	wg.Wait()
 	return nil
 }
@@ -82,9 +139,36 @@ type Service struct {
 	// key: 被发现的服务名，value: 请求发现该服务的客户端地址列表
 	sNameToDAddr map[string][]string
 
-	// addrStore 地址到RPC客户端的映射
-	// key: 服务器地址，value: 与该服务器的RPC连接客户端
-	addrStore map[string]ClientClient
+	// addrStore 地址到RPC连接的映射
+	// key: 服务器地址，value: 与该服务器的RPC连接，用于通知和健康检查
+	addrStore map[string]*nodeConn
+
+	// sAddrToMeta 服务地址到实例元数据的映射
+	sAddrToMeta map[string]*Metadata
+
+	// health 服务地址到健康状态的映射
+	health map[string]*instanceHealth
+	// healthCheck 健康检查配置
+	healthCheck HealthCheckConfig
+
+	// streams 服务名到流式订阅者的映射
+	// 流式订阅者通过 Watch 接收推送，不记录在 sNameToDAddr 中
+	streams map[string]map[*streamWatcher]struct{}
+
+	// bootID 本次启动的标识，客户端据此感知注册中心重启
+	bootID string
+
+	// store 快照存储，为空时不持久化
+	store Store
+	// dirty 状态在上次落盘后是否有变更
+	dirty atomic.Bool
+	// adminAddr 管理后台监听地址，为空时不开启
+	adminAddr string
+	// adminToken 管理后台写操作的令牌
+	adminToken string
+	// serverCreds/clientCreds 注册中心RPC服务和连接各节点使用的传输凭证
+	serverCreds credentials.TransportCredentials
+	clientCreds credentials.TransportCredentials
 	UnimplementedRegisterServer
 }
 
@@ -92,28 +176,34 @@ type Service struct {
 // 当一个服务启动时，会调用此方法将自己注册到服务发现中心
 func (s *Service) Register(ctx context.Context, req *RegisterReq) (res *RegisterRes, err error) {
 	// 获取客户端地址
-	clientAddr := req.Port
-	p, ok := peer.FromContext(ctx)
-	if !ok {
-		return nil, fmt.Errorf("无法获取对等方信息")
-	}
-	if tcpAddr, ok := p.Addr.(*net.TCPAddr); ok {
-		clientIP := tcpAddr.IP.String()
-		clientAddr = fmt.Sprintf("[%s]%s", clientIP, clientAddr)
+	clientAddr, err := peerAddr(ctx, req.Addr, req.Port)
+	if err != nil {
+		return nil, err
 	}
 	slog.Info("收到服务注册请求", "addr", clientAddr, "servers", req.Servers)
 
 	// 建立与注册服务的RPC连接，用于后续的健康检查和通知
-	conn, err := grpc.NewClient(clientAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client, err := s.dial(clientAddr)
 	if err != nil {
-		log.Fatalf("fail to dial: %v", err)
+		slog.Error("fail to dial", "addr", clientAddr, "err", err)
+		return nil, err
 	}
-	client := NewClientClient(conn)
 	slog.Info("与注册服务建立连接成功", "addr", clientAddr)
 
 	// 获取写锁，更新服务映射关系
 	s.lock.Lock()
+	// 同一地址重新注册(实例重启、注册中心重启后恢复)时关闭旧连接，并移除旧的服务映射，服务列表可能已变化
+	if old := s.addrStore[clientAddr]; old != nil {
+		old.conn.Close()
+	}
 	s.addrStore[clientAddr] = client
+	var dropped []string
+	for _, serverName := range s.sAddrToSNames[clientAddr] {
+		if !slices.Contains(req.Servers, serverName) {
+			dropped = append(dropped, serverName)
+		}
+	}
+	s.unlinkServices(clientAddr)
 
 	// 更新服务名到地址的映射
 	for _, serverName := range req.Servers {
@@ -133,12 +223,22 @@ func (s *Service) Register(ctx context.Context, req *RegisterReq) (res *Register
 
 	// 更新地址到服务名的映射
 	s.sAddrToSNames[clientAddr] = req.Servers
+	s.sAddrToMeta[clientAddr] = req.Metadata
+	s.health[clientAddr] = &instanceHealth{Status: StatusHealthy}
 
 	// 释放写锁
 	s.lock.Unlock()
+	s.markDirty()
 	slog.Info("服务注册信息更新完成", "addr", clientAddr)
 
 	// 通知所有关注这些服务的客户端
+	for _, serverName := range dropped {
+		s.notifySubscribers(&NotifyReq{
+			Type:   "del",
+			Server: serverName,
+			Addr:   clientAddr,
+		})
+	}
 	for _, serverName := range req.Servers {
 		s.notifySubscribers(&NotifyReq{
 			Type:   "register",
@@ -149,60 +249,127 @@ func (s *Service) Register(ctx context.Context, req *RegisterReq) (res *Register
 
 	// 返回成功响应
 	slog.Info("服务注册成功", "addr", clientAddr, "servers", req.Servers)
-	return
+	return &RegisterRes{BootId: s.bootID}, nil
 }
 
-// Discover 处理服务发现请求
-// 当一个服务需要调用另一个服务时，会调用此方法获取目标服务的地址列表
-func (s *Service) Discover(ctx context.Context, req *Req) (res *Resp, err error) {
-	// 获取客户端地址
-	clientAddr := req.Port
+// Heartbeat 处理客户端心跳
+// 客户端据 boot_id 和 registered 判断注册中心是否重启、是否需要重新注册
+func (s *Service) Heartbeat(ctx context.Context, req *HeartbeatReq) (*HeartbeatRes, error) {
+	clientAddr, err := peerAddr(ctx, req.Addr, req.Port)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.RLock()
+	_, registered := s.sAddrToSNames[clientAddr]
+	s.lock.RUnlock()
+	return &HeartbeatRes{BootId: s.bootID, Registered: registered}, nil
+}
+
+// dial 建立与节点的RPC连接
+func (s *Service) dial(addr string) (*nodeConn, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(s.clientCreds))
+	if err != nil {
+		return nil, err
+	}
+	return &nodeConn{
+		conn:   conn,
+		client: NewClientClient(conn),
+		health: healthpb.NewHealthClient(conn),
+	}, nil
+}
+
+// Deregister 处理服务注销请求
+// 服务正常停机时主动调用，立即从所有映射中移除并通知订阅者，无需等待健康检查
+func (s *Service) Deregister(ctx context.Context, req *DeregisterReq) (res *DeregisterRes, err error) {
+	clientAddr, err := peerAddr(ctx, req.Addr, req.Port)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("收到服务注销请求", "addr", clientAddr)
+	s.removeInstance(clientAddr)
+	return &DeregisterRes{}, nil
+}
+
+// peerAddr 客户端地址，根据对等方IP和上报的端口拼接
+// 客户端上报的对外地址只在主机与对等方IP一致，或开启 mTLS 且客户端证书包含该主机时采用，
+// 否则回退为对等方地址，避免伪造地址注销或顶替其他实例
+func peerAddr(ctx context.Context, addr, port string) (string, error) {
 	p, ok := peer.FromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("无法获取对等方信息")
+		return "", fmt.Errorf("无法获取对等方信息")
+	}
+	tcpAddr, ok := p.Addr.(*net.TCPAddr)
+	if !ok {
+		// 进程内等非TCP连接无法校验来源
+		if addr != "" {
+			return addr, nil
+		}
+		return port, nil
 	}
-	if tcpAddr, ok := p.Addr.(*net.TCPAddr); ok {
-		clientIP := tcpAddr.IP.String()
-		clientAddr = fmt.Sprintf("[%s]%s", clientIP, clientAddr)
+	if addr == "" {
+		return joinPort(tcpAddr.IP.String(), port), nil
 	}
-	slog.Info("收到服务发现请求", "addr", clientAddr, "targetServer", req.Server)
+	host, _, err := net.SplitHostPort(addr)
+	if err == nil && (sameIP(host, tcpAddr.IP) || certHasHost(p.AuthInfo, host)) {
+		return addr, nil
+	}
+	fallback := joinPort(tcpAddr.IP.String(), port)
+	slog.Warn("上报地址与连接来源不符，使用对等方地址", "addr", addr, "peer", tcpAddr.String(), "use", fallback)
+	return fallback, nil
+}
 
-	// 获取读锁，查找目标服务的地址列表
-	s.lock.RLock()
-	sAddr := s.sNameToSAddr[req.Server]
-	s.lock.RUnlock()
+// sameIP host 是否为与 ip 相同的IP
+func sameIP(host string, ip net.IP) bool {
+	hostIP := net.ParseIP(host)
+	return hostIP != nil && hostIP.Equal(ip)
+}
 
-	// 检查服务是否存在
-	if len(sAddr) == 0 {
-		slog.Error("未找到目标服务", "targetServer", req.Server)
-		return res, ErrServerNotFind
+// certHasHost 客户端证书经过校验且 SAN 中包含 host
+func certHasHost(authInfo credentials.AuthInfo, host string) bool {
+	info, ok := authInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.PeerCertificates) == 0 {
+		return false
+	}
+	return info.State.PeerCertificates[0].VerifyHostname(host) == nil
+}
+
+// Discover 处理服务发现请求
+// 当一个服务需要调用另一个服务时，会调用此方法获取目标服务的地址列表
+func (s *Service) Discover(ctx context.Context, req *Req) (res *Resp, err error) {
+	// 获取客户端地址
+	clientAddr, err := peerAddr(ctx, req.Addr, req.Port)
+	if err != nil {
+		return nil, err
 	}
+	slog.Info("收到服务发现请求", "addr", clientAddr, "targetServer", req.Server)
 
 	// 获取写锁，将请求者添加到服务订阅列表
 	// 这样当目标服务有变化时（新增实例、实例下线等），可以主动通知请求者
+	// 目标服务尚未上线时也记录订阅，上线后即可收到通知
+	// 使用 Watch 流的客户端设置 NoSubscribe，只查询不记录，避免重复的反向通知
 	s.lock.Lock()
 
-	// 检查是否已经订阅，避免重复添加
-	subscribers := s.sNameToDAddr[req.Server]
-	alreadySubscribed := false
-	for _, subscriber := range subscribers {
-		if subscriber == clientAddr {
-			alreadySubscribed = true
-			break
-		}
-	}
-
-	if !alreadySubscribed {
+	if !req.NoSubscribe && !slices.Contains(s.sNameToDAddr[req.Server], clientAddr) {
 		s.sNameToDAddr[req.Server] = append(s.sNameToDAddr[req.Server], clientAddr)
+		s.markDirty()
 		slog.Info("添加服务订阅者", "targetServer", req.Server, "subscribeAddr", clientAddr)
 	}
 
+	sAddr := s.sNameToSAddr[req.Server]
+	instances := s.instances(req.Server)
 	s.lock.Unlock()
 
+	// 检查服务是否存在
+	if len(sAddr) == 0 {
+		slog.Error("未找到目标服务", "targetServer", req.Server)
+		return res, ErrServerNotFind
+	}
+
 	// 构造响应
 	res = &Resp{
-		Server: req.Server,
-		Addr:   make([]string, len(sAddr)),
+		Server:    req.Server,
+		Addr:      make([]string, len(sAddr)),
+		Instances: instances,
 	}
 	copy(res.Addr, sAddr)
 
@@ -210,6 +377,83 @@ func (s *Service) Discover(ctx context.Context, req *Req) (res *Resp, err error)
 	return
 }
 
+// instances 服务当前的实例列表，调用方需持有锁
+func (s *Service) instances(server string) []*Instance {
+	sAddr := s.sNameToSAddr[server]
+	instances := make([]*Instance, len(sAddr))
+	for i, addr := range sAddr {
+		instances[i] = &Instance{Addr: addr, Metadata: s.sAddrToMeta[addr]}
+		if h := s.health[addr]; h != nil {
+			instances[i].Status = h.Status
+		}
+	}
+	return instances
+}
+
+// streamWatcher 一个 Watch 流，changed 有信号时推送最新快照
+type streamWatcher struct {
+	changed chan struct{}
+}
+
+// Watch 处理流式订阅
+// 建立后立即推送一次当前实例，此后服务每次变化推送一次全量快照
+// 连续多次变化只推送最新状态，慢订阅者不会阻塞注册中心
+func (s *Service) Watch(req *WatchReq, stream grpc.ServerStreamingServer[WatchRes]) error {
+	w := &streamWatcher{changed: make(chan struct{}, 1)}
+	w.changed <- struct{}{}
+	s.lock.Lock()
+	if s.streams[req.Server] == nil {
+		s.streams[req.Server] = make(map[*streamWatcher]struct{})
+	}
+	s.streams[req.Server][w] = struct{}{}
+	s.lock.Unlock()
+	slog.Info("添加流式订阅者", "targetServer", req.Server, "peer", peerString(stream.Context()))
+
+	defer func() {
+		s.lock.Lock()
+		delete(s.streams[req.Server], w)
+		if len(s.streams[req.Server]) == 0 {
+			delete(s.streams, req.Server)
+		}
+		s.lock.Unlock()
+		slog.Info("移除流式订阅者", "targetServer", req.Server, "peer", peerString(stream.Context()))
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-w.changed:
+		}
+		s.lock.RLock()
+		instances := s.instances(req.Server)
+		s.lock.RUnlock()
+		if err := stream.Send(&WatchRes{Server: req.Server, Instances: instances}); err != nil {
+			return err
+		}
+	}
+}
+
+// notifyStreams 通知服务的流式订阅者推送最新快照
+func (s *Service) notifyStreams(serverName string) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for w := range s.streams[serverName] {
+		select {
+		case w.changed <- struct{}{}:
+		default:
+			// 已有待推送的变化，推送时会取最新状态
+		}
+	}
+}
+
+func peerString(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
 /*
 服务发现流程说明：
 
@@ -244,6 +488,9 @@ func (s *Service) Discover(ctx context.Context, req *Req) (res *Resp, err error)
 // 当服务有变更时（新增实例、实例下线等），调用此方法通知关注的客户端
 func (s *Service) notifySubscribers(notifyReq *NotifyReq) {
 	serverName := notifyReq.Server
+	s.notifyStreams(serverName)
+
+	// 以下为反向连接通知，兼容不支持 Watch 的旧客户端
 	// 获取读锁，查找订阅者列表
 	s.lock.RLock()
 	subscribers := s.sNameToDAddr[serverName]
@@ -260,10 +507,10 @@ func (s *Service) notifySubscribers(notifyReq *NotifyReq) {
 	for _, subscriberAddr := range subscribers {
 		// 获取订阅者的RPC客户端
 		s.lock.RLock()
-		subscriberClient := s.addrStore[subscriberAddr]
+		subscriberNode := s.addrStore[subscriberAddr]
 		s.lock.RUnlock()
 
-		if subscriberClient == nil {
+		if subscriberNode == nil {
 			slog.Error("订阅者RPC客户端不存在", "subscriber_addr", subscriberAddr)
 			// 清理无效的订阅者
 			s.removeSubscriber(serverName, subscriberAddr)
@@ -280,7 +527,7 @@ func (s *Service) notifySubscribers(notifyReq *NotifyReq) {
 			} else {
 				slog.Info("通知订阅者成功", "subscriber_addr", addr, "server_name", req.Server)
 			}
-		}(subscriberClient, subscriberAddr, notifyReq)
+		}(subscriberNode.client, subscriberAddr, notifyReq)
 	}
 }
 
@@ -294,106 +541,66 @@ func (s *Service) removeSubscriber(serverName, subscriberAddr string) {
 		if addr == subscriberAddr {
 			// 移除订阅者
 			s.sNameToDAddr[serverName] = append(subscribers[:i], subscribers[i+1:]...)
+			s.markDirty()
 			slog.Info("移除无效订阅者", "server_name", serverName, "subscriber_addr", subscriberAddr)
 			break
 		}
 	}
 }
 
-// startHealthCheck 启动健康检查协程
-// 定期检查所有注册服务的健康状态，清理不可用的服务
-func (s *Service) startHealthCheck() {
-	slog.Info("启动健康检查服务")
-
-	// 每30秒执行一次健康检查
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			s.performHealthCheck()
+// unlinkServices 从地址提供的各服务中移除该地址，并删除地址到服务名的映射，调用方需持有写锁
+func (s *Service) unlinkServices(addr string) {
+	for _, serviceName := range s.sAddrToSNames[addr] {
+		addrs := s.sNameToSAddr[serviceName]
+		for i, serviceAddr := range addrs {
+			if serviceAddr == addr {
+				// 移除该地址
+				s.sNameToSAddr[serviceName] = append(addrs[:i], addrs[i+1:]...)
+				break
+			}
 		}
-	}
-}
-
-// performHealthCheck 执行健康检查
-func (s *Service) performHealthCheck() {
-	//slog.Info("开始执行健康检查")
-
-	// 获取所有需要检查的地址
-	s.lock.RLock()
-	addrsToCheck := make([]string, 0, len(s.addrStore))
-	for addr := range s.addrStore {
-		addrsToCheck = append(addrsToCheck, addr)
-	}
-	s.lock.RUnlock()
 
-	// 检查每个地址的健康状态
-	for _, addr := range addrsToCheck {
-		if !s.isServiceHealthy(addr) {
-			slog.Warn("检测到不健康的服务", "addr", addr)
-			s.handleUnhealthyService(addr)
+		// 如果服务没有任何可用实例了，清理整个条目
+		if len(s.sNameToSAddr[serviceName]) == 0 {
+			delete(s.sNameToSAddr, serviceName)
 		}
 	}
+	delete(s.sAddrToSNames, addr)
 }
 
-// isServiceHealthy 检查指定地址的服务是否健康
-func (s *Service) isServiceHealthy(addr string) bool {
-	s.lock.RLock()
-	client := s.addrStore[addr]
-	s.lock.RUnlock()
-
-	if client == nil {
-		slog.Error("RPC客户端不存在", "addr", addr)
-		return false
-	}
-
-	_, err := client.Ping(context.Background(), &PingReq{})
-	if err != nil {
-		slog.Error("服务不健康", "error", err, "addr", addr)
-		return false
-	}
-	return true
-}
-
-// handleUnhealthyService 处理不健康的服务
-// 清理相关的映射关系并通知订阅者
-func (s *Service) handleUnhealthyService(addr string) {
-	slog.Info("开始处理不健康服务", "addr", addr)
-
+// removeInstance 从所有映射中移除指定地址，并通知受影响服务的订阅者
+func (s *Service) removeInstance(addr string) {
 	s.lock.Lock()
 
 	// 获取该地址提供的服务列表
 	serviceNames := s.sAddrToSNames[addr]
 
-	// 关闭并移除RPC客户端
-	if client := s.addrStore[addr]; client != nil {
-		//client.Close()
+	// 关闭并移除RPC连接
+	if node := s.addrStore[addr]; node != nil {
+		node.conn.Close()
 		delete(s.addrStore, addr)
 	}
 
 	// 从服务名到地址的映射中移除该地址
-	for _, serviceName := range serviceNames {
-		addrs := s.sNameToSAddr[serviceName]
-		for i, serviceAddr := range addrs {
-			if serviceAddr == addr {
-				// 移除该地址
-				s.sNameToSAddr[serviceName] = append(addrs[:i], addrs[i+1:]...)
+	s.unlinkServices(addr)
+	delete(s.sAddrToMeta, addr)
+	delete(s.health, addr)
+
+	// 该地址不再作为订阅者
+	for serviceName, subscribers := range s.sNameToDAddr {
+		for i, subscriber := range subscribers {
+			if subscriber == addr {
+				s.sNameToDAddr[serviceName] = append(subscribers[:i], subscribers[i+1:]...)
 				break
 			}
 		}
-
-		// 如果服务没有任何可用实例了，清理整个条目
-		if len(s.sNameToSAddr[serviceName]) == 0 {
-			delete(s.sNameToSAddr, serviceName)
+		if len(s.sNameToDAddr[serviceName]) == 0 {
+			delete(s.sNameToDAddr, serviceName)
 		}
 	}
 
-	// 从地址到服务名的映射中移除该地址
-	delete(s.sAddrToSNames, addr)
-
 	s.lock.Unlock()
+	s.markDirty()
 
 	// 通知所有受影响服务的订阅者
 	for _, serviceName := range serviceNames {
@@ -405,5 +612,5 @@ func (s *Service) handleUnhealthyService(addr string) {
 		})
 	}
 
-	slog.Info("不健康服务处理完成", "addr", addr, "affected_services", serviceNames)
+	slog.Info("实例已移除", "addr", addr, "affected_services", serviceNames)
 }
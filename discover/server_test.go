@@ -0,0 +1,114 @@
+package discover
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+)
+
+func TestDiscoverSubscribe(t *testing.T) {
+	s := &Service{
+		lock:         &sync.RWMutex{},
+		sNameToSAddr: map[string][]string{"user": {"10.0.0.1:9000"}},
+		sNameToDAddr: make(map[string][]string),
+		sAddrToMeta:  make(map[string]*Metadata),
+		health:       make(map[string]*instanceHealth),
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 50000}})
+
+	t.Run("Watch 客户端只查询不订阅", func(t *testing.T) {
+		res, err := s.Discover(ctx, &Req{Port: "9001", Server: "user", NoSubscribe: true})
+		if err != nil || len(res.Instances) != 1 {
+			t.Fatalf("Discover() = %v, %v", res, err)
+		}
+		if subscribers := s.sNameToDAddr["user"]; len(subscribers) != 0 {
+			t.Errorf("subscribers = %v, want none", subscribers)
+		}
+	})
+	t.Run("反向通知客户端记录订阅", func(t *testing.T) {
+		for range 2 {
+			if _, err := s.Discover(ctx, &Req{Port: "9001", Server: "user"}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if subscribers := s.sNameToDAddr["user"]; len(subscribers) != 1 || subscribers[0] != "10.0.0.2:9001" {
+			t.Errorf("subscribers = %v", subscribers)
+		}
+	})
+}
+
+func TestRegisterSameAddr(t *testing.T) {
+	s := &Service{
+		lock:          &sync.RWMutex{},
+		sNameToSAddr:  make(map[string][]string),
+		sAddrToSNames: make(map[string][]string),
+		sNameToDAddr:  make(map[string][]string),
+		addrStore:     make(map[string]*nodeConn),
+		sAddrToMeta:   make(map[string]*Metadata),
+		health:        make(map[string]*instanceHealth),
+		streams:       make(map[string]map[*streamWatcher]struct{}),
+		clientCreds:   insecure.NewCredentials(),
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 50000}})
+	if _, err := s.Register(ctx, &RegisterReq{Port: "9000", Servers: []string{"user", "order"}}); err != nil {
+		t.Fatal(err)
+	}
+	first := s.addrStore["10.0.0.1:9000"]
+	// 实例重启后只提供 user
+	if _, err := s.Register(ctx, &RegisterReq{Port: "9000", Servers: []string{"user"}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.addrStore["10.0.0.1:9000"].conn.Close() })
+
+	t.Run("旧连接已关闭", func(t *testing.T) {
+		if state := first.conn.GetState(); state != connectivity.Shutdown {
+			t.Errorf("state = %v, want Shutdown", state)
+		}
+	})
+	t.Run("不再提供的服务移除该地址", func(t *testing.T) {
+		if addrs, ok := s.sNameToSAddr["order"]; ok {
+			t.Errorf("order = %v, want removed", addrs)
+		}
+		if addrs := s.sNameToSAddr["user"]; len(addrs) != 1 || addrs[0] != "10.0.0.1:9000" {
+			t.Errorf("user = %v", addrs)
+		}
+	})
+}
+
+func TestPeerAddr(t *testing.T) {
+	tcp := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 50000}
+	cert := &x509.Certificate{IPAddresses: []net.IP{net.IPv4(192, 168, 1, 1)}}
+	mtls := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+		VerifiedChains:   [][]*x509.Certificate{{cert}},
+	}}
+	tests := []struct {
+		name     string
+		authInfo credentials.AuthInfo
+		addr     string
+		want     string
+	}{
+		{"未上报地址", nil, "", "10.0.0.1:9000"},
+		{"上报地址与来源一致", nil, "10.0.0.1:9100", "10.0.0.1:9100"},
+		{"上报地址与来源不一致", nil, "10.0.0.2:9000", "10.0.0.1:9000"},
+		{"客户端证书包含上报地址", mtls, "192.168.1.1:9000", "192.168.1.1:9000"},
+		{"客户端证书不包含上报地址", mtls, "192.168.1.2:9000", "10.0.0.1:9000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tcp, AuthInfo: tt.authInfo})
+			got, err := peerAddr(ctx, tt.addr, "9000")
+			if err != nil || got != tt.want {
+				t.Errorf("peerAddr() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
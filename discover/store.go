@@ -0,0 +1,205 @@
+package discover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Gong-Yang/g-micor/util/arrays"
+	"github.com/redis/go-redis/v9"
+)
+
+// persistInterval 状态有变更时的落盘间隔
+const persistInterval = 3 * time.Second
+
+// Snapshot 注册中心的状态快照
+type Snapshot struct {
+	Services    map[string][]string  `json:"services"`    // 服务名 -> 服务地址
+	Instances   map[string][]string  `json:"instances"`   // 服务地址 -> 服务名
+	Subscribers map[string][]string  `json:"subscribers"` // 服务名 -> 订阅者地址
+	Metadata    map[string]*Metadata `json:"metadata"`    // 服务地址 -> 实例元数据
+	SavedAt     time.Time            `json:"savedAt"`
+}
+
+// Store 快照存储，注册中心启动时恢复，运行中定期保存
+type Store interface {
+	// Load 读取快照，不存在时返回 nil, nil
+	Load(ctx context.Context) (*Snapshot, error)
+	Save(ctx context.Context, snapshot *Snapshot) error
+}
+
+// NewFileStore 基于本地文件的快照存储
+func NewFileStore(path string) Store {
+	return &fileStore{path: path}
+}
+
+type fileStore struct {
+	path string
+}
+
+func (f *fileStore) Load(ctx context.Context) (*Snapshot, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	err = json.Unmarshal(data, snapshot)
+	return snapshot, err
+}
+
+func (f *fileStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	// 先写临时文件再替换，避免写一半时崩溃导致快照损坏
+	tmp := f.path + ".tmp"
+	if err = os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	if err = os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// NewRedisStore 基于Redis的快照存储，多个注册中心实例可共享同一份状态
+func NewRedisStore(client *redis.Client, key string) Store {
+	return &redisStore{client: client, key: key}
+}
+
+type redisStore struct {
+	client *redis.Client
+	key    string
+}
+
+func (r *redisStore) Load(ctx context.Context) (*Snapshot, error) {
+	data, err := r.client.Get(ctx, r.key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	err = json.Unmarshal(data, snapshot)
+	return snapshot, err
+}
+
+func (r *redisStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.key, data, 0).Err()
+}
+
+// snapshot 生成当前状态的快照
+func (s *Service) snapshot() *Snapshot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &Snapshot{
+		Services:    copyMap(s.sNameToSAddr),
+		Instances:   copyMap(s.sAddrToSNames),
+		Subscribers: copyMap(s.sNameToDAddr),
+		Metadata:    maps.Clone(s.sAddrToMeta),
+		SavedAt:     time.Now(),
+	}
+}
+
+// restore 从存储恢复状态，并重新建立与各节点的连接
+func (s *Service) restore(ctx context.Context) error {
+	snapshot, err := s.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		slog.Info("无可恢复的注册中心快照")
+		return nil
+	}
+	normalizeSnapshot(snapshot)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sNameToSAddr = copyMap(snapshot.Services)
+	s.sAddrToSNames = copyMap(snapshot.Instances)
+	s.sNameToDAddr = copyMap(snapshot.Subscribers)
+	s.sAddrToMeta = make(map[string]*Metadata, len(snapshot.Metadata))
+	maps.Copy(s.sAddrToMeta, snapshot.Metadata)
+	for addr := range s.sAddrToSNames {
+		node, err := s.dial(addr)
+		if err != nil {
+			slog.Error("恢复节点连接失败", "addr", addr, "error", err)
+			continue
+		}
+		s.addrStore[addr] = node
+		s.health[addr] = &instanceHealth{Status: StatusHealthy}
+	}
+	slog.Info("注册中心快照恢复完成", "instances", len(s.sAddrToSNames), "savedAt", snapshot.SavedAt)
+	return nil
+}
+
+// markDirty 标记状态已变更，等待下次落盘
+func (s *Service) markDirty() {
+	if s.store != nil {
+		s.dirty.Store(true)
+	}
+}
+
+// startPersist 定期将变更后的状态写入存储
+func (s *Service) startPersist() {
+	ticker := time.NewTicker(persistInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !s.dirty.Swap(false) {
+			continue
+		}
+		if err := s.store.Save(context.Background(), s.snapshot()); err != nil {
+			slog.Error("保存注册中心快照失败", "error", err)
+			s.dirty.Store(true)
+		}
+	}
+}
+
+func copyMap(src map[string][]string) map[string][]string {
+	dst := make(map[string][]string, len(src))
+	for k, v := range src {
+		dst[k] = append([]string(nil), v...)
+	}
+	return dst
+}
+
+// normalizeSnapshot 将旧版本快照中 "[ip]:port" 形式的地址转为标准的 host:port
+func normalizeSnapshot(snapshot *Snapshot) {
+	normalize := func(addr string) string {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return addr
+		}
+		return net.JoinHostPort(host, port)
+	}
+	for name, addrs := range snapshot.Services {
+		snapshot.Services[name] = arrays.Map(addrs, normalize)
+	}
+	for name, addrs := range snapshot.Subscribers {
+		snapshot.Subscribers[name] = arrays.Map(addrs, normalize)
+	}
+	instances := make(map[string][]string, len(snapshot.Instances))
+	for addr, names := range snapshot.Instances {
+		instances[normalize(addr)] = names
+	}
+	snapshot.Instances = instances
+	metadata := make(map[string]*Metadata, len(snapshot.Metadata))
+	for addr, meta := range snapshot.Metadata {
+		metadata[normalize(addr)] = meta
+	}
+	snapshot.Metadata = metadata
+}
@@ -1,10 +1,13 @@
 package errorx
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 type ErrorCode struct {
@@ -43,14 +46,40 @@ func (r ErrorCode) SetData(data any) ErrorCode {
 
 var codeMap = map[string]ErrorCode{}
 
+// defined 通过 New 定义的错误码，用于生成文档；重复定义时保留最先定义的
+var (
+	definedLock sync.Mutex
+	defined     = map[string]ErrorCode{}
+)
+
 func New(model, code, msg string) ErrorCode {
 	key := model + ":" + code
 	if _, ok := codeMap[key]; ok {
 		panic("code already exists")
 	}
-	return ErrorCode{
+	e := ErrorCode{
 		Model: model,
 		Code:  code,
 		Msg:   msg,
 	}
+	definedLock.Lock()
+	if _, ok := defined[key]; !ok {
+		defined[key] = e
+	}
+	definedLock.Unlock()
+	return e
+}
+
+// Codes 通过 New 定义的所有错误码，按 Model、Code 排序
+func Codes() []ErrorCode {
+	definedLock.Lock()
+	defer definedLock.Unlock()
+	res := make([]ErrorCode, 0, len(defined))
+	for _, e := range defined {
+		res = append(res, e)
+	}
+	slices.SortFunc(res, func(a, b ErrorCode) int {
+		return cmp.Or(cmp.Compare(a.Model, b.Model), cmp.Compare(a.Code, b.Code))
+	})
+	return res
 }
@@ -0,0 +1,139 @@
+package ginx
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/Gong-Yang/g-micor/security"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+// PermissionUser 拥有权限列表的用户，Permission 鉴权时使用
+// proto 消息中的 repeated string permissions 字段即满足该接口
+type PermissionUser interface {
+	AuthUser
+	GetPermissions() []string
+}
+
+var (
+	author      Author
+	authorAppID string
+)
+
+// SetAuthor 设置鉴权者，appid 在鉴权时传给 Author，需在注册路由前调用
+func SetAuthor(appid string, a Author) {
+	authorAppID = appid
+	author = a
+}
+
+// Open 开放接口，无需认证；请求中带有有效凭证时仍会放入上下文
+func Open() HandlerFunc {
+	return Auth(PermissionLevelOpen)
+}
+
+// Login 需要登录
+func Login() HandlerFunc {
+	return Auth(PermissionLevelLogin)
+}
+
+// Role 需要登录且角色为 roles 之一
+func Role(roles ...string) HandlerFunc {
+	return Auth(PermissionLevelRole, roles...)
+}
+
+// Permission 需要登录且拥有全部 permissions，用户需实现 PermissionUser
+func Permission(permissions ...string) HandlerFunc {
+	return Auth(PermissionLevelPermission, permissions...)
+}
+
+// Auth 按权限等级鉴权的中间件，values 为 PermissionLevelRole 的角色或 PermissionLevelPermission 的权限
+// 鉴权通过后用户放入上下文，可通过 GetAuthUser 获取；失败时返回 ErrAuthFail
+func Auth(level int, values ...string) HandlerFunc {
+	return func(ctx *gin.Context) error {
+		user, err := authenticate(ctx)
+		if err != nil {
+			if level == PermissionLevelOpen {
+				return nil
+			}
+			slog.InfoContext(ctx, "auth fail", "path", ctx.FullPath(), "err", err)
+			return ErrAuthFail
+		}
+		switch level {
+		case PermissionLevelRole:
+			if !slices.Contains(values, user.GetRole()) {
+				return ErrAuthFail
+			}
+		case PermissionLevelPermission:
+			p, ok := user.(PermissionUser)
+			if !ok {
+				return ErrAuthFail
+			}
+			for _, value := range values {
+				if !slices.Contains(p.GetPermissions(), value) {
+					return ErrAuthFail
+				}
+			}
+		}
+		GinCtxSet(ctx, ContextAuthUser, user)
+		return nil
+	}
+}
+
+var errNoAuthor = errors.New("author not set")
+
+func authenticate(ctx *gin.Context) (AuthUser, error) {
+	if author == nil {
+		return nil, errNoAuthor
+	}
+	return author.Auth(authorAppID, ctx)
+}
+
+// GetAuthUser 获取鉴权中间件放入上下文的用户，未登录时返回 false
+func GetAuthUser[T AuthUser](ctx context.Context) (user T, ok bool) {
+	user, ok = ctx.Value(ContextAuthUser).(T)
+	return
+}
+
+// TokenAuthor 校验 security.NewToken 签发的凭证，凭证内容为 T
+//
+//	ginx.SetAuthor(app.Conf.App.Name, ginx.NewTokenAuthor[*userC.Session](app.Conf.App.HmacKey))
+type TokenAuthor[T interface {
+	proto.Message
+	AuthUser
+}] struct {
+	Key    string // 签名密钥，一般为 AppConfig.HmacKey
+	Header string // 凭证所在的请求头，默认 Authorization，可带 Bearer 前缀
+}
+
+func NewTokenAuthor[T interface {
+	proto.Message
+	AuthUser
+}](key string) *TokenAuthor[T] {
+	if key == "" {
+		panic("token author: empty hmac key")
+	}
+	return &TokenAuthor[T]{Key: key, Header: "Authorization"}
+}
+
+var errNoToken = errors.New("no token")
+
+func (a *TokenAuthor[T]) Auth(appid string, c *gin.Context) (AuthUser, error) {
+	header := a.Header
+	if header == "" {
+		header = "Authorization"
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(c.GetHeader(header), "Bearer "))
+	if token == "" {
+		return nil, errNoToken
+	}
+	var zero T
+	user := zero.ProtoReflect().Type().New().Interface().(T)
+	if err := security.VerifyToken(token, a.Key, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,129 @@
+package ginx_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/Gong-Yang/g-micor/ginx/internal/testpb"
+	"github.com/Gong-Yang/g-micor/security"
+	"github.com/gin-gonic/gin"
+)
+
+type testUser struct {
+	role        string
+	permissions []string
+}
+
+func (u *testUser) GetRole() string          { return u.role }
+func (u *testUser) GetPermissions() []string { return u.permissions }
+
+// testAuthor 请求头 X-Role 为用户角色，无该请求头时认证失败
+type testAuthor struct{}
+
+func (testAuthor) Auth(appid string, c *gin.Context) (ginx.AuthUser, error) {
+	role := c.GetHeader("X-Role")
+	if role == "" {
+		return nil, errors.New("no token")
+	}
+	return &testUser{role: role, permissions: []string{"order:read"}}, nil
+}
+
+func TestAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ginx.SetAuthor("test", testAuthor{})
+	t.Cleanup(func() { ginx.SetAuthor("", nil) })
+
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	whoami := func(ctx context.Context) (string, error) {
+		if user, ok := ginx.GetAuthUser[*testUser](ctx); ok {
+			return user.role, nil
+		}
+		return "guest", nil
+	}
+	ginx.GET(engine, []ginx.HandlerFunc{ginx.Open()}, "/open", whoami)
+	ginx.GET(engine, []ginx.HandlerFunc{ginx.Login()}, "/login", whoami)
+	ginx.GET(engine, []ginx.HandlerFunc{ginx.Role("admin")}, "/admin", whoami)
+	ginx.GET(engine, []ginx.HandlerFunc{ginx.Permission("order:read")}, "/read", whoami)
+	ginx.GET(engine, []ginx.HandlerFunc{ginx.Permission("order:write")}, "/write", whoami)
+
+	const fail = `{"model":"system","code":"E001","msg":"no auth"}`
+	tests := []struct {
+		name string
+		path string
+		role string
+		want string
+	}{
+		{"开放接口未登录", "/open", "", `{"code":"S000","data":"guest"}`},
+		{"开放接口已登录", "/open", "user", `{"code":"S000","data":"user"}`},
+		{"需要登录", "/login", "", fail},
+		{"已登录", "/login", "user", `{"code":"S000","data":"user"}`},
+		{"角色不符", "/admin", "user", fail},
+		{"角色相符", "/admin", "admin", `{"code":"S000","data":"admin"}`},
+		{"拥有权限", "/read", "user", `{"code":"S000","data":"user"}`},
+		{"缺少权限", "/write", "user", fail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.role != "" {
+				req.Header.Set("X-Role", tt.role)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenAuthor(t *testing.T) {
+	const key = "test-hmac-key"
+	token, err := security.NewToken(&testpb.Session{Role: "admin", Permissions: []string{"order:read"}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, err := security.NewToken(&testpb.Session{Role: "admin"}, "other-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	author := ginx.NewTokenAuthor[*testpb.Session](key)
+	tests := []struct {
+		name   string
+		header string
+		ok     bool
+	}{
+		{"有效凭证", token, true},
+		{"带Bearer前缀", "Bearer " + token, true},
+		{"签名错误", forged, false},
+		{"缺少请求头", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				c.Request.Header.Set("Authorization", tt.header)
+			}
+			user, err := author.Auth("test", c)
+			if !tt.ok {
+				if err == nil {
+					t.Errorf("Auth() = %v, want error", user)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Auth() error = %v", err)
+			}
+			session, ok := user.(*testpb.Session)
+			if !ok || session.GetRole() != "admin" || len(session.GetPermissions()) != 1 {
+				t.Errorf("Auth() = %v", user)
+			}
+		})
+	}
+}
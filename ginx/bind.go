@@ -0,0 +1,164 @@
+package ginx
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/Gong-Yang/g-micor/errorx"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError 单个字段的校验错误，作为 ErrParamInvalid 的 Data 返回
+type FieldError struct {
+	Field string `json:"field,omitempty"` // 参数名，取自 json/query/path/header 标签
+	Rule  string `json:"rule,omitempty"`  // 未通过的校验规则
+	Msg   string `json:"msg"`
+}
+
+// 校验规则对应的错误提示，{param} 替换为规则参数
+var ruleMessages = map[string]string{
+	"required": "不能为空",
+	"min":      "不能小于{param}",
+	"max":      "不能大于{param}",
+	"len":      "长度必须为{param}",
+	"gt":       "必须大于{param}",
+	"gte":      "不能小于{param}",
+	"lt":       "必须小于{param}",
+	"lte":      "不能大于{param}",
+	"oneof":    "必须是[{param}]之一",
+	"email":    "邮箱格式不正确",
+	"url":      "URL格式不正确",
+	"uuid":     "UUID格式不正确",
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.SetTagName("validate")
+	// 错误中的字段名使用请求中的参数名
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "query", "path", "header"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" {
+				continue
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	return v
+}
+
+// RegisterValidation 注册自定义校验规则，msg 为校验失败时的提示，需在注册路由前调用
+func RegisterValidation(tag string, fn validator.Func, msg string) error {
+	if err := validate.RegisterValidation(tag, fn); err != nil {
+		return err
+	}
+	ruleMessages[tag] = msg
+	return nil
+}
+
+// Bind 参数，按结构体标签从请求中绑定后按 validate 标签校验
+//
+//	type ListReq struct {
+//		ID    string `path:"id" validate:"required"`
+//		Page  int    `query:"page" validate:"gte=1"`
+//		Token string `header:"X-Token"`
+//		Name  string `json:"name" validate:"max=20"`
+//	}
+//
+// 依次绑定 query、header、JSON 请求体和路径参数，后绑定的覆盖先绑定的；
+// 绑定或校验失败时返回 ErrParamInvalid，Data 为 []FieldError
+func Bind[T any]() *BindParam[T] {
+	return &BindParam[T]{}
+}
+
+type BindParam[T any] struct{}
+
+func (b *BindParam[T]) GetParam(ctx *gin.Context) (res any, err error) {
+	return bind[T](ctx)
+}
+
+// bind 按 Bind 的规则绑定并校验请求参数
+func bind[T any](ctx *gin.Context) (req *T, err error) {
+	req = new(T)
+	if err = binding.MapFormWithTag(req, ctx.Request.URL.Query(), "query"); err != nil {
+		return nil, invalidParam("query", err)
+	}
+	if err = binding.Header.Bind(ctx.Request, req); err != nil {
+		return nil, invalidParam("header", err)
+	}
+	if ctx.Request.Body != nil && ctx.Request.ContentLength != 0 {
+		if err = json.NewDecoder(ctx.Request.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+			return nil, invalidParam("body", err)
+		}
+	}
+	path := make(map[string][]string, len(ctx.Params))
+	for _, param := range ctx.Params {
+		path[param.Key] = []string{param.Value}
+	}
+	if err = binding.MapFormWithTag(req, path, "path"); err != nil {
+		return nil, invalidParam("path", err)
+	}
+	if err = Validate(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// Validate 按 validate 标签校验结构体，失败时返回 ErrParamInvalid，非结构体不校验
+func Validate(obj any) error {
+	if reflect.Indirect(reflect.ValueOf(obj)).Kind() != reflect.Struct {
+		return nil
+	}
+	if err := validate.Struct(obj); err != nil {
+		return paramError(err)
+	}
+	return nil
+}
+
+// paramError 将参数绑定、校验错误转为 ErrParamInvalid
+func paramError(err error) error {
+	var code errorx.ErrorCode
+	if errors.As(err, &code) {
+		return err
+	}
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return ErrParamInvalid.SetData([]FieldError{{Msg: err.Error()}})
+	}
+	fields := make([]FieldError, len(errs))
+	for i, e := range errs {
+		msg, ok := ruleMessages[e.Tag()]
+		if !ok {
+			msg = "不满足校验规则" + e.Tag()
+		}
+		fields[i] = FieldError{
+			Field: fieldPath(e.Namespace()),
+			Rule:  e.Tag(),
+			Msg:   strings.ReplaceAll(msg, "{param}", e.Param()),
+		}
+	}
+	return ErrParamInvalid.SetData(fields)
+}
+
+// fieldPath 去掉命名空间中的结构体名，如 ListReq.page -> page
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+// invalidParam 参数来源 field 的绑定错误
+func invalidParam(field string, err error) error {
+	return ErrParamInvalid.SetData([]FieldError{{Field: field, Msg: err.Error()}})
+}
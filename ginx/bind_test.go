@@ -0,0 +1,63 @@
+package ginx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/gin-gonic/gin"
+)
+
+type listReq struct {
+	ID    string `path:"id" validate:"required"`
+	Page  int    `query:"page" validate:"gte=1"`
+	Token string `header:"X-Token" validate:"required"`
+	Name  string `json:"name" validate:"max=3"`
+}
+
+func TestBind(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	ginx.POST(engine, nil, "/items/:id", func(ctx context.Context, req *listReq) (*listReq, error) {
+		return req, nil
+	}, ginx.Bind[listReq]())
+	ginx.GET(engine, nil, "/page", func(ctx context.Context, page int) (int, error) {
+		return page, nil
+	}, ginx.Query("page", ginx.INT))
+
+	serve := func(req *http.Request) string {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+	t.Run("绑定各来源的参数", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/items/a1?page=2", strings.NewReader(`{"name":"abc"}`))
+		req.Header.Set("X-Token", "t")
+		got := serve(req)
+		want := `{"code":"S000","data":{"ID":"a1","Page":2,"Token":"t","name":"abc"}}`
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+	t.Run("校验失败返回字段错误", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/items/a1?page=0", strings.NewReader(`{"name":"abcd"}`))
+		got := serve(req)
+		want := `{"model":"system","code":"E002","msg":"invalid params","data":[` +
+			`{"field":"page","rule":"gte","msg":"不能小于1"},` +
+			`{"field":"X-Token","rule":"required","msg":"不能为空"},` +
+			`{"field":"name","rule":"max","msg":"不能大于3"}]}`
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+	t.Run("类型错误不再panic", func(t *testing.T) {
+		got := serve(httptest.NewRequest(http.MethodGet, "/page?page=x", nil))
+		if !strings.Contains(got, `"code":"E002"`) || !strings.Contains(got, `"field":"page"`) {
+			t.Errorf("got %s", got)
+		}
+	})
+}
@@ -9,9 +9,11 @@ import (
 
 // 错误
 var (
-	ErrIsNotFunc = errors.New("not func")          // handler方法非法
-	ErrDataType  = errors.New("invalid data type") // 参数类型非法
-	ErrAuthFail  = errorx.New("system", "E001", "no auth")
+	ErrIsNotFunc    = errors.New("not func")          // handler方法非法
+	ErrDataType     = errors.New("invalid data type") // 参数类型非法
+	ErrAuthFail     = errorx.New("system", "E001", "no auth")
+	ErrParamInvalid = errorx.New("system", "E002", "invalid params")  // 请求参数非法，Data 为 []FieldError
+	ErrTimeout      = errorx.New("system", "E003", "request timeout") // 请求超时
 )
 
 // 上下文常量
@@ -19,6 +21,7 @@ var (
 	ContextTraceID    = "TraceID"
 	ContextFuncResult = "FuncResult"
 	ContextAuthUser   = "AuthUser"
+	ContextErrorCode  = "ErrorCode" // 响应的错误码 errorx.ErrorCode
 )
 
 // 参数类型
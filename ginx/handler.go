@@ -2,6 +2,7 @@ package ginx
 
 import (
 	"context"
+	"net/http"
 	"reflect"
 
 	"github.com/gin-gonic/gin"
@@ -11,11 +12,13 @@ func POST(group gin.IRouter, mid []HandlerFunc, path string, fun any, params ...
 	checkFun(fun)
 	var handler = getHandler(fun, params)
 	group.POST(path, append(handlerConvert(mid), handler)...)
+	addRoute(group, http.MethodPost, path, funcResponse(fun), params)
 }
 func GET(group gin.IRouter, mid []HandlerFunc, path string, fun any, params ...Param) {
 	checkFun(fun)
 	var handler = getHandler(fun, params)
 	group.GET(path, append(handlerConvert(mid), handler)...)
+	addRoute(group, http.MethodGet, path, funcResponse(fun), params)
 }
 
 func Any(group gin.IRouter, mid []HandlerFunc, path string, fun any, params ...Param) {
@@ -23,9 +26,31 @@ func Any(group gin.IRouter, mid []HandlerFunc, path string, fun any, params ...P
 	checkFun(fun)
 	var handler = getHandler(fun, params)
 	group.Any(path, append(handlerConvert(mid), handler)...)
+	addRoute(group, "ANY", path, funcResponse(fun), params)
 }
 
-func process(ctx context.Context, ginCtx *gin.Context, fun any, params []Param) (res []interface{}) {
+// Handle 注册类型安全的路由，签名错误在编译期即可发现
+// Req 按 Bind 的规则从 query、header、JSON 请求体和路径参数绑定并校验，fun 直接调用而不经过反射
+// 返回值由 BasicMiddleware 统一包装
+func Handle[Req, Resp any](group gin.IRouter, method, path string, fun func(ctx context.Context, req *Req) (*Resp, error), mid ...HandlerFunc) {
+	handler := func(ctx *gin.Context) {
+		req, err := bind[Req](ctx)
+		if err != nil {
+			ctx.Set(ContextFuncResult, []any{nil, err})
+			return
+		}
+		res, err := fun(ctx.Request.Context(), req)
+		if err != nil {
+			ctx.Set(ContextFuncResult, []any{nil, err})
+			return
+		}
+		ctx.Set(ContextFuncResult, []any{res, nil})
+	}
+	group.Handle(method, path, append(handlerConvert(mid), handler)...)
+	addRoute(group, method, path, reflect.TypeFor[Resp](), []documenter{&BindParam[Req]{}})
+}
+
+func process(ctx context.Context, ginCtx *gin.Context, fun any, params []Param) (res []interface{}, err error) {
 	// 反射获取反射类型对象
 	funValue := reflect.ValueOf(fun)
 
@@ -34,10 +59,9 @@ func process(ctx context.Context, ginCtx *gin.Context, fun any, params []Param)
 	args[0] = reflect.ValueOf(ctx)
 	for i, param := range params {
 		var arg any
-		arg, err := param.GetParam(ginCtx)
+		arg, err = param.GetParam(ginCtx)
 		if err != nil {
-			panic(err)
-			return
+			return nil, paramError(err)
 		}
 		args[i+1] = reflect.ValueOf(arg)
 	}
@@ -56,13 +80,17 @@ func checkFun(fun any) {
 	funValue := reflect.ValueOf(fun)
 	if funValue.Kind() != reflect.Func {
 		panic(ErrIsNotFunc)
-		return
 	}
 }
 
 func getHandler(fun any, params []Param) func(ctx *gin.Context) {
 	return func(ctx *gin.Context) {
-		resArr := process(ctx.Request.Context(), ctx, fun, params)
+		resArr, err := process(ctx.Request.Context(), ctx, fun, params)
+		if err != nil {
+			// 参数错误不再 panic，按业务错误返回
+			ctx.Set(ContextFuncResult, []any{nil, err})
+			return
+		}
 		ctx.Set(ContextFuncResult, resArr)
 	}
 }
@@ -0,0 +1,54 @@
+package ginx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/errorx"
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/gin-gonic/gin"
+)
+
+type helloReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type helloRes struct {
+	Message string `json:"message"`
+}
+
+var errNoBob = errorx.New("test", "T001", "no bob")
+
+func TestHandle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	ginx.Handle(engine, http.MethodPost, "/hello", func(ctx context.Context, req *helloReq) (*helloRes, error) {
+		if req.Name == "bob" {
+			return nil, errNoBob
+		}
+		return &helloRes{Message: "hi " + req.Name}, nil
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"正常返回", `{"name":"amy"}`, `{"code":"S000","data":{"message":"hi amy"}}`},
+		{"业务错误", `{"name":"bob"}`, `{"model":"test","code":"T001","msg":"no bob"}`},
+		{"参数校验失败", `{}`, `{"model":"system","code":"E002","msg":"invalid params","data":[{"field":"name","rule":"required","msg":"不能为空"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/hello", strings.NewReader(tt.body)))
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
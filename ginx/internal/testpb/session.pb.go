@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: ginx/internal/testpb/session.proto
+
+package testpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Session 测试用的登录凭证内容
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Permissions   []string               `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_ginx_internal_testpb_session_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_ginx_internal_testpb_session_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_ginx_internal_testpb_session_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Session) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Session) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+var File_ginx_internal_testpb_session_proto protoreflect.FileDescriptor
+
+const file_ginx_internal_testpb_session_proto_rawDesc = "" +
+	"\n" +
+	"\"ginx/internal/testpb/session.proto\x12\x06testpb\"?\n" +
+	"\aSession\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12 \n" +
+	"\vpermissions\x18\x02 \x03(\tR\vpermissionsB3Z1github.com/Gong-Yang/g-micor/ginx/internal/testpbb\x06proto3"
+
+var (
+	file_ginx_internal_testpb_session_proto_rawDescOnce sync.Once
+	file_ginx_internal_testpb_session_proto_rawDescData []byte
+)
+
+func file_ginx_internal_testpb_session_proto_rawDescGZIP() []byte {
+	file_ginx_internal_testpb_session_proto_rawDescOnce.Do(func() {
+		file_ginx_internal_testpb_session_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ginx_internal_testpb_session_proto_rawDesc), len(file_ginx_internal_testpb_session_proto_rawDesc)))
+	})
+	return file_ginx_internal_testpb_session_proto_rawDescData
+}
+
+var file_ginx_internal_testpb_session_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_ginx_internal_testpb_session_proto_goTypes = []any{
+	(*Session)(nil), // 0: testpb.Session
+}
+var file_ginx_internal_testpb_session_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_ginx_internal_testpb_session_proto_init() }
+func file_ginx_internal_testpb_session_proto_init() {
+	if File_ginx_internal_testpb_session_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ginx_internal_testpb_session_proto_rawDesc), len(file_ginx_internal_testpb_session_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ginx_internal_testpb_session_proto_goTypes,
+		DependencyIndexes: file_ginx_internal_testpb_session_proto_depIdxs,
+		MessageInfos:      file_ginx_internal_testpb_session_proto_msgTypes,
+	}.Build()
+	File_ginx_internal_testpb_session_proto = out.File
+	file_ginx_internal_testpb_session_proto_goTypes = nil
+	file_ginx_internal_testpb_session_proto_depIdxs = nil
+}
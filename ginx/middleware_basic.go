@@ -1,25 +1,48 @@
 package ginx
 
 import (
-	"context"
 	"errors"
 	"log/slog"
 	"net/http"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/Gong-Yang/g-micor/errorx"
 	"github.com/Gong-Yang/g-micor/logx"
-	"github.com/Gong-Yang/g-micor/util/random"
+	"github.com/Gong-Yang/g-micor/metricx"
+	"github.com/Gong-Yang/g-micor/otelx"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // BasicMiddleware 结果统一包装，异常捕获统一处理
 func BasicMiddleware(ctx *gin.Context) {
-	// 请求追踪号
-	traceID := random.ShortUUID()
+	start := time.Now()
+	// 请求追踪号，沿用调用方的 traceparent 或 X-Request-Id，没有时生成
+	traceID := logx.IncomingTraceID(ctx.GetHeader(logx.HeaderTraceparent), ctx.GetHeader(logx.HeaderRequestID))
+	if traceID == "" {
+		traceID = logx.NewTraceID()
+	}
 	ctx.Set(ContextTraceID, traceID)
-	logx.RequestAddAttrs(ctx, ContextTraceID, traceID)
+	reqCtx := logx.WithTraceID(ctx.Request.Context(), traceID)
+	// 链路 span，在响应包装和 panic 处理之后结束
+	reqCtx = otel.GetTextMapPropagator().Extract(reqCtx, propagation.HeaderCarrier(ctx.Request.Header))
+	reqCtx, op := httpInstrument.Start(reqCtx, strings.TrimSpace(ctx.Request.Method+" "+ctx.FullPath()),
+		attribute.String("http.request.method", ctx.Request.Method),
+		attribute.String("http.route", ctx.FullPath()))
+	defer func() { endHTTP(ctx, op, start) }()
+	ctx.Request = ctx.Request.WithContext(reqCtx)
+	ctx.Header(logx.HeaderRequestID, traceID)
+	propagation.TraceContext{}.Inject(reqCtx, propagation.HeaderCarrier(ctx.Writer.Header()))
+	if ctx.Writer.Header().Get(logx.HeaderTraceparent) == "" {
+		if traceparent := logx.Traceparent(traceID); traceparent != "" {
+			ctx.Header(logx.HeaderTraceparent, traceparent)
+		}
+	}
 	// 添加panic恢复处理
 	defer handlePanic(ctx)
 	slog.InfoContext(ctx, "request start",
@@ -31,6 +54,39 @@ func BasicMiddleware(ctx *gin.Context) {
 	wrapResponse(ctx)
 }
 
+var httpInstrument = otelx.NewInstrument("http.server.request.duration", "HTTP 请求耗时", trace.SpanKindServer)
+
+// endHTTP 结束请求的 span 并记录指标，处理函数返回的错误和 5xx 响应记为错误
+func endHTTP(ctx *gin.Context, op *otelx.Op, start time.Time) {
+	status := ctx.Writer.Status()
+	code := errorx.RespSuccess
+	if value, ok := ctx.Get(ContextErrorCode); ok {
+		code = codeLabel(value.(errorx.ErrorCode))
+	} else if status >= http.StatusInternalServerError {
+		code = errorx.RespErr
+	}
+	metricx.ObserveHTTP(ctx.Request.Method, ctx.FullPath(), status, code, time.Since(start))
+	op.SetAttributes(attribute.Int("http.response.status_code", status))
+	var err error
+	if value, ok := ctx.Get(ContextFuncResult); ok {
+		if results, ok := value.([]any); ok && len(results) == 2 {
+			err, _ = results[1].(error)
+		}
+	}
+	if err == nil && status >= http.StatusInternalServerError {
+		err = errors.New(http.StatusText(status))
+	}
+	op.End(err)
+}
+
+// codeLabel 错误码的指标标签，业务错误为 model:code
+func codeLabel(e errorx.ErrorCode) string {
+	if e.Model == "" {
+		return e.Code
+	}
+	return e.Model + ":" + e.Code
+}
+
 // handlePanic 处理panic并返回适当的响应
 func handlePanic(ctx *gin.Context) {
 	a := recover()
@@ -94,6 +150,7 @@ func wrapError(c *gin.Context, a any, isPanic bool) {
 		"err", appErr,
 		"response", appErr,
 		"path", c.Request.URL.Path)
+	c.Set(ContextErrorCode, appErr)
 	c.AbortWithStatusJSON(http.StatusOK, appErr)
 }
 
@@ -104,42 +161,6 @@ func getStackTrace() string {
 	return string(buf[512:n])
 }
 
-var timeOutMap = map[int]HandlerFunc{}
-
-// MidTimeOut 处理请求超时控制
-func MidTimeOut(seconds int) HandlerFunc {
-	if hand, ok := timeOutMap[seconds]; ok {
-		return hand
-	}
-	hand := func(ctx *gin.Context) error {
-		// 创建带超时的上下文
-		reqCtx, cancel := context.WithTimeout(ctx.Request.Context(), time.Second*time.Duration(seconds))
-
-		// 将超时上下文替换到请求中
-		ctx.Request = ctx.Request.WithContext(reqCtx)
-
-		// 使用 goroutine 监控超时，但不阻塞主流程
-		go func() {
-			<-reqCtx.Done()
-			//slog.InfoContext(ctx, "reqCtx done", "path", ctx.FullPath(), "err", reqCtx.Err())
-			if errors.Is(reqCtx.Err(), context.DeadlineExceeded) {
-				slog.ErrorContext(reqCtx, "request timeout",
-					"path", ctx.FullPath(),
-					"method", ctx.Request.Method,
-					"timeout_seconds", seconds)
-				// 注意：这里的 AbortWithStatus 可能在请求已完成后调用
-				// 但 Gin 会自动处理这种情况
-				ctx.AbortWithStatus(http.StatusGatewayTimeout)
-			}
-		}()
-		defer cancel()
-		ctx.Next()
-		return nil
-	}
-	timeOutMap[seconds] = hand
-	return hand
-}
-
 type HandlerFunc func(ctx *gin.Context) error
 
 func handlerConvert(in []HandlerFunc) (res []gin.HandlerFunc) {
@@ -153,24 +174,3 @@ func handlerConvert(in []HandlerFunc) (res []gin.HandlerFunc) {
 	}
 	return
 }
-
-//func handAuth(ctx *gin.Context, conf *RouterConf) (AuthUser, error) {
-//	if conf.author == nil { // 无认证者
-//		return nil, nil
-//	}
-//
-//	user, err := conf.author.Auth(conf.appId, ctx)
-//	if err != nil {
-//		if conf.needLogin {
-//			return nil, err // 需要登录
-//		}
-//		return nil, nil
-//	}
-//
-//	if conf.role != "" && user.GetRole() != conf.role {
-//		return nil, ErrAuthFail
-//	}
-//
-//	GinCtxSet(ctx, ContextAuthUser, user)
-//	return user, nil
-//}
@@ -0,0 +1,583 @@
+package ginx
+
+import (
+	"fmt"
+	"mime/multipart"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Gong-Yang/g-micor/errorx"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// docsMounter 挂载文档和 Swagger UI，由 ginx/openapi 包导入时注册
+var docsMounter func(engine *gin.Engine, prefix string, info OpenAPIInfo)
+
+// RegisterDocs 注册文档的挂载方式，供 ginx/openapi 包在 init 中调用
+func RegisterDocs(mount func(engine *gin.Engine, prefix string, info OpenAPIInfo)) {
+	docsMounter = mount
+}
+
+// MountDocs 在 engine 上挂载 OpenAPI 文档和 Swagger UI，需导入 ginx/openapi 包
+//
+//	import _ "github.com/Gong-Yang/g-micor/ginx/openapi"
+func MountDocs(engine *gin.Engine, prefix string, info OpenAPIInfo) {
+	if docsMounter == nil {
+		panic(`ginx: docs enabled but ginx/openapi is not imported, add import _ "github.com/Gong-Yang/g-micor/ginx/openapi"`)
+	}
+	docsMounter(engine, prefix, info)
+}
+
+// OpenAPIInfo 文档的基本信息
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPI 根据 engine 上通过 ginx 注册的路由生成 OpenAPI 3 文档，Swagger UI 由 ginx/openapi 包挂载
+// 响应统一为 Response 包装，业务错误为 ErrorCode，文档中列出通过 errorx.New 定义的错误码
+func OpenAPI(engine *gin.Engine, info OpenAPIInfo) *OpenAPIDoc {
+	if info.Title == "" {
+		info.Title = "API"
+	}
+	if info.Version == "" {
+		info.Version = "0.0.0"
+	}
+	g := newSchemaGen()
+	doc := &OpenAPIDoc{
+		OpenAPI:    "3.0.3",
+		Info:       info,
+		Paths:      make(map[string]map[string]*operation),
+		ErrorCodes: errorx.Codes(),
+	}
+	for _, route := range Routes(engine) {
+		op := &operation{Responses: g.responses(route.Response)}
+		if tag := firstSegment(route.Path); tag != "" {
+			op.Tags = []string{tag}
+		}
+		for _, param := range route.params {
+			param.document(op, g)
+		}
+		// 路径中未声明类型的参数按字符串处理
+		for _, name := range pathParamNames(route.Path) {
+			if !op.hasParam(name, "path") {
+				op.addParam(name, "path", true, &schema{Type: "string"})
+			}
+		}
+		p := openAPIPath(route.Path)
+		if doc.Paths[p] == nil {
+			doc.Paths[p] = make(map[string]*operation)
+		}
+		methods := []string{strings.ToLower(route.Method)}
+		if route.Method == "ANY" {
+			methods = []string{"get", "post"}
+		}
+		for _, method := range methods {
+			doc.Paths[p][method] = op
+		}
+	}
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+// OpenAPIDoc OpenAPI 3 文档
+type OpenAPIDoc struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       OpenAPIInfo                      `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+	ErrorCodes []errorx.ErrorCode `json:"x-error-codes,omitempty"`
+}
+
+type operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*parameter         `json:"parameters,omitempty"`
+	RequestBody *requestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	AllOf                []*schema          `json:"allOf,omitempty"`
+	OneOf                []*schema          `json:"oneOf,omitempty"`
+}
+
+func (op *operation) hasParam(name, in string) bool {
+	return slices.ContainsFunc(op.Parameters, func(p *parameter) bool {
+		return p.Name == name && p.In == in
+	})
+}
+
+func (op *operation) addParam(name, in string, required bool, s *schema) {
+	if op.hasParam(name, in) {
+		return
+	}
+	op.Parameters = append(op.Parameters, &parameter{Name: name, In: in, Required: required || in == "path", Schema: s})
+}
+
+// setBody 设置 JSON 请求体
+func (op *operation) setBody(s *schema) {
+	op.RequestBody = &requestBody{Required: true, Content: map[string]mediaType{"application/json": {Schema: s}}}
+}
+
+// addFormField 向 multipart 请求体中添加字段
+func (op *operation) addFormField(name string, s *schema) {
+	const contentType = "multipart/form-data"
+	if op.RequestBody == nil || op.RequestBody.Content[contentType].Schema == nil {
+		op.RequestBody = &requestBody{Content: map[string]mediaType{
+			contentType: {Schema: &schema{Type: "object", Properties: map[string]*schema{}}},
+		}}
+	}
+	op.RequestBody.Content[contentType].Schema.Properties[name] = s
+}
+
+// documenter 可在 OpenAPI 文档中描述自身的参数
+type documenter interface {
+	document(op *operation, g *schemaGen)
+}
+
+func (f *FormFileParam) document(op *operation, g *schemaGen) {
+	op.addFormField(f.name, &schema{Type: "string", Format: "binary"})
+}
+
+func (b *BodyParam[T]) document(op *operation, g *schemaGen) {
+	op.setBody(g.typeSchema(reflect.TypeFor[T]()))
+}
+
+func (h *HeaderParam) document(op *operation, g *schemaGen) {
+	op.addParam(h.Key, "header", false, &schema{Type: "string"})
+}
+
+func (b *QueryParamItem) document(op *operation, g *schemaGen) {
+	op.addParam(b.Key, "query", false, valueTypeSchema(b.Type))
+}
+
+func (b *PathParamItem) document(op *operation, g *schemaGen) {
+	op.addParam(b.Key, "path", true, valueTypeSchema(b.Type))
+}
+
+// document 按标签将结构体字段分为 path、query、header 参数，其余字段作为 JSON 请求体
+func (b *BindParam[T]) document(op *operation, g *schemaGen) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		op.setBody(g.typeSchema(t))
+		return
+	}
+	body := &schema{Type: "object", Properties: map[string]*schema{}}
+	for _, f := range structFields(t) {
+		required := hasRule(f, "required")
+		switch {
+		case tagName(f, "path") != "":
+			op.addParam(tagName(f, "path"), "path", true, g.typeSchema(f.Type))
+		case tagName(f, "query") != "":
+			op.addParam(tagName(f, "query"), "query", required, g.typeSchema(f.Type))
+		case tagName(f, "header") != "":
+			op.addParam(tagName(f, "header"), "header", required, g.typeSchema(f.Type))
+		default:
+			name := jsonName(f)
+			if name == "" {
+				continue
+			}
+			body.Properties[name] = g.typeSchema(f.Type)
+			if required {
+				body.Required = append(body.Required, name)
+			}
+		}
+	}
+	if len(body.Properties) > 0 {
+		op.setBody(body)
+	}
+}
+
+// protoRequest Proto 注册的路由的请求描述
+type protoRequest struct {
+	desc   protoreflect.MessageDescriptor
+	body   string
+	params map[string]string // gin 参数名 -> 字段路径
+}
+
+func (r *protoRequest) document(op *operation, g *schemaGen) {
+	bound := make(map[string]bool)
+	for name, field := range r.params {
+		s := &schema{Type: "string"}
+		if fd := fieldByPath(r.desc, field); fd != nil {
+			s = g.fieldSchema(fd)
+		}
+		op.addParam(name, "path", true, s)
+		bound[field] = true
+	}
+	switch r.body {
+	case "":
+	case "*":
+		op.setBody(g.messageSchema(r.desc))
+		return
+	default:
+		if fd := r.desc.Fields().ByName(protoreflect.Name(r.body)); fd != nil {
+			op.setBody(g.fieldSchema(fd))
+			bound[r.body] = true
+		}
+	}
+	// 未被路径和请求体绑定的顶层标量字段作为 query 参数
+	fields := r.desc.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if bound[string(fd.Name())] || fd.IsMap() || fd.Kind() == protoreflect.MessageKind {
+			continue
+		}
+		op.addParam(fd.JSONName(), "query", false, g.fieldSchema(fd))
+	}
+}
+
+// fieldByPath 按字段路径（如 user.id）查找字段
+func fieldByPath(md protoreflect.MessageDescriptor, fieldPath string) protoreflect.FieldDescriptor {
+	var fd protoreflect.FieldDescriptor
+	for _, name := range strings.Split(fieldPath, ".") {
+		if md == nil {
+			return nil
+		}
+		if fd = md.Fields().ByName(protoreflect.Name(name)); fd == nil {
+			return nil
+		}
+		md = fd.Message()
+	}
+	return fd
+}
+
+// schemaGen 由 Go 类型和 proto 描述生成 schema，具名类型放入 components
+type schemaGen struct {
+	schemas map[string]*schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaGen() *schemaGen {
+	g := &schemaGen{schemas: make(map[string]*schema), names: make(map[reflect.Type]string)}
+	g.schemas["Response"] = &schema{
+		Type:        "object",
+		Description: fmt.Sprintf("统一响应包装，成功时 code 为 %s", errorx.RespSuccess),
+		Properties: map[string]*schema{
+			"code": {Type: "string"},
+			"msg":  {Type: "string"},
+			"data": {},
+		},
+	}
+	g.schemas["ErrorCode"] = &schema{
+		Type:        "object",
+		Description: errorCodeTable(),
+		Properties: map[string]*schema{
+			"model": {Type: "string"},
+			"code":  {Type: "string"},
+			"msg":   {Type: "string"},
+			"data":  {},
+		},
+	}
+	return g
+}
+
+// errorCodeTable 已定义错误码的 markdown 表格
+func errorCodeTable() string {
+	var b strings.Builder
+	b.WriteString("业务错误，HTTP 状态码为 200；未知错误时 HTTP 状态码为 500，code 为 " + errorx.RespErr + "\n\n")
+	b.WriteString("| model | code | msg |\n| --- | --- | --- |\n")
+	for _, e := range errorx.Codes() {
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", e.Model, e.Code, e.Msg)
+	}
+	return b.String()
+}
+
+// responses 成功时为 Response 包装的数据，业务错误为 ErrorCode
+func (g *schemaGen) responses(data reflect.Type) map[string]*response {
+	success := &schema{Ref: "#/components/schemas/Response"}
+	if data != nil {
+		success = &schema{AllOf: []*schema{success, {
+			Type:       "object",
+			Properties: map[string]*schema{"data": g.typeSchema(data)},
+		}}}
+	}
+	errorCode := &schema{Ref: "#/components/schemas/ErrorCode"}
+	return map[string]*response{
+		"200": {Description: "成功或业务错误", Content: map[string]mediaType{
+			"application/json": {Schema: &schema{OneOf: []*schema{success, errorCode}}},
+		}},
+		"500": {Description: "未知错误", Content: map[string]mediaType{
+			"application/json": {Schema: errorCode},
+		}},
+	}
+}
+
+var (
+	protoMessageType = reflect.TypeFor[proto.Message]()
+	timeType         = reflect.TypeFor[time.Time]()
+	fileHeaderType   = reflect.TypeFor[multipart.FileHeader]()
+)
+
+// typeSchema Go 类型的 schema，proto 消息按 protojson 的格式描述
+func (g *schemaGen) typeSchema(t reflect.Type) *schema {
+	if t.Kind() == reflect.Pointer && t.Implements(protoMessageType) {
+		return g.messageSchema(reflect.Zero(t).Interface().(proto.Message).ProtoReflect().Descriptor())
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &schema{Type: "number"}
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &schema{Type: "string", Format: "byte"}
+		}
+		return &schema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &schema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		switch t {
+		case timeType:
+			return &schema{Type: "string", Format: "date-time"}
+		case fileHeaderType:
+			return &schema{Type: "string", Format: "binary"}
+		}
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name, ok := g.names[t]
+		if !ok {
+			name = g.componentName(t)
+			g.names[t] = name
+			g.schemas[name] = &schema{}
+			*g.schemas[name] = *g.structSchema(t)
+		}
+		return &schema{Ref: "#/components/schemas/" + name}
+	}
+	return &schema{}
+}
+
+func (g *schemaGen) structSchema(t reflect.Type) *schema {
+	s := &schema{Type: "object", Properties: map[string]*schema{}}
+	for _, f := range structFields(t) {
+		name := jsonName(f)
+		if name == "" {
+			continue
+		}
+		s.Properties[name] = g.typeSchema(f.Type)
+		if hasRule(f, "required") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// componentName 具名类型在 components 中的名字，如 user.HelloRes，重名时加序号
+func (g *schemaGen) componentName(t reflect.Type) string {
+	name := invalidNameChars.ReplaceAllString(path.Base(t.PkgPath())+"."+t.Name(), "_")
+	for i, base := 2, name; g.schemas[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	return name
+}
+
+// 与 protojson 格式一致的 well-known 类型
+var wellKnownSchemas = map[protoreflect.FullName]schema{
+	"google.protobuf.Timestamp":   {Type: "string", Format: "date-time"},
+	"google.protobuf.Duration":    {Type: "string"},
+	"google.protobuf.FieldMask":   {Type: "string"},
+	"google.protobuf.Struct":      {Type: "object"},
+	"google.protobuf.ListValue":   {Type: "array", Items: &schema{}},
+	"google.protobuf.Value":       {},
+	"google.protobuf.Any":         {Type: "object"},
+	"google.protobuf.Empty":       {Type: "object"},
+	"google.protobuf.StringValue": {Type: "string"},
+	"google.protobuf.BytesValue":  {Type: "string", Format: "byte"},
+	"google.protobuf.BoolValue":   {Type: "boolean"},
+	"google.protobuf.Int32Value":  {Type: "integer", Format: "int32"},
+	"google.protobuf.UInt32Value": {Type: "integer", Format: "int32"},
+	"google.protobuf.Int64Value":  {Type: "string", Format: "int64"},
+	"google.protobuf.UInt64Value": {Type: "string", Format: "int64"},
+	"google.protobuf.FloatValue":  {Type: "number"},
+	"google.protobuf.DoubleValue": {Type: "number"},
+}
+
+// messageSchema proto 消息的 schema，字段名为 json 名
+func (g *schemaGen) messageSchema(md protoreflect.MessageDescriptor) *schema {
+	if s, ok := wellKnownSchemas[md.FullName()]; ok {
+		return &s
+	}
+	name := string(md.FullName())
+	if _, ok := g.schemas[name]; !ok {
+		s := &schema{Type: "object", Properties: map[string]*schema{}}
+		g.schemas[name] = s
+		fields := md.Fields()
+		for i := range fields.Len() {
+			fd := fields.Get(i)
+			s.Properties[fd.JSONName()] = g.fieldSchema(fd)
+		}
+	}
+	return &schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *schemaGen) fieldSchema(fd protoreflect.FieldDescriptor) *schema {
+	if fd.IsMap() {
+		return &schema{Type: "object", AdditionalProperties: g.singularSchema(fd.MapValue())}
+	}
+	s := g.singularSchema(fd)
+	if fd.IsList() {
+		return &schema{Type: "array", Items: s}
+	}
+	return s
+}
+
+// singularSchema 单个值的 schema，64 位整数在 protojson 中为字符串
+func (g *schemaGen) singularSchema(fd protoreflect.FieldDescriptor) *schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &schema{Type: "boolean"}
+	case protoreflect.StringKind:
+		return &schema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &schema{Type: "string", Format: "byte"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind, protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &schema{Type: "integer", Format: "int32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &schema{Type: "string", Format: "int64"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return &schema{Type: "number"}
+	case protoreflect.EnumKind:
+		s := &schema{Type: "string"}
+		values := fd.Enum().Values()
+		for i := range values.Len() {
+			s.Enum = append(s.Enum, string(values.Get(i).Name()))
+		}
+		return s
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return g.messageSchema(fd.Message())
+	}
+	return &schema{}
+}
+
+// structFields 结构体的导出字段，展开没有 json 名的匿名结构体字段
+func structFields(t reflect.Type) []reflect.StructField {
+	var res []reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous && tagName(f, "json") == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				res = append(res, structFields(ft)...)
+				continue
+			}
+		}
+		if f.IsExported() {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// tagName 标签中的名字，忽略选项
+func tagName(f reflect.StructField, tag string) string {
+	name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+	return name
+}
+
+// jsonName 字段的 json 名，忽略的字段返回空
+func jsonName(f reflect.StructField) string {
+	switch name := tagName(f, "json"); name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	default:
+		return name
+	}
+}
+
+// hasRule 字段的 validate 标签中是否包含规则
+func hasRule(f reflect.StructField, rule string) bool {
+	return slices.Contains(strings.Split(f.Tag.Get("validate"), ","), rule)
+}
+
+// valueTypeSchema Query、Path 参数类型对应的 schema
+func valueTypeSchema(valueType string) *schema {
+	switch valueType {
+	case INT:
+		return &schema{Type: "integer", Format: "int32"}
+	case FLOAT:
+		return &schema{Type: "number"}
+	case BOOL:
+		return &schema{Type: "boolean"}
+	}
+	return &schema{Type: "string"}
+}
+
+var ginParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+// openAPIPath gin 路径转为 OpenAPI 路径，如 /user/:id -> /user/{id}
+func openAPIPath(p string) string {
+	return ginParam.ReplaceAllString(p, "{$1}")
+}
+
+// pathParamNames gin 路径中的参数名
+func pathParamNames(p string) []string {
+	var res []string
+	for _, m := range ginParam.FindAllStringSubmatch(p, -1) {
+		res = append(res, m[1])
+	}
+	return res
+}
+
+// firstSegment 路径的第一段，作为文档中的分组
+func firstSegment(p string) string {
+	seg, _, _ := strings.Cut(strings.TrimPrefix(p, "/"), "/")
+	if strings.ContainsAny(seg, ":*") {
+		return ""
+	}
+	return seg
+}
@@ -0,0 +1,44 @@
+// Package openapi 挂载 ginx 生成的 OpenAPI 文档和内置的 Swagger UI
+// UI 资源约 1.6 MB，只有开启文档的服务引用该包时才会打包进二进制；导入后 app.docs 配置生效
+//
+//	import _ "github.com/Gong-Yang/g-micor/ginx/openapi"
+package openapi
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.html
+var swaggerPage []byte
+
+// swaggerUI 内置的 swagger-ui-dist，离线环境也可使用
+//
+//go:embed swagger-ui
+var swaggerUI embed.FS
+
+func init() {
+	ginx.RegisterDocs(Docs)
+}
+
+// Docs 在 engine 上挂载 OpenAPI 3 文档和 Swagger UI
+// GET {prefix}/openapi.json 返回根据已注册路由生成的文档，GET {prefix}/ 为 Swagger UI，页面资源在 {prefix}/swagger-ui/ 下
+func Docs(engine *gin.Engine, prefix string, info ginx.OpenAPIInfo) {
+	assets, err := fs.Sub(swaggerUI, "swagger-ui")
+	if err != nil {
+		panic(err)
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	engine.GET(prefix+"/openapi.json", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, ginx.OpenAPI(engine, info))
+	})
+	engine.GET(prefix+"/", func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", swaggerPage)
+	})
+	engine.StaticFS(prefix+"/swagger-ui", http.FS(assets))
+}
@@ -0,0 +1,52 @@
+package openapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/Gong-Yang/g-micor/ginx/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+func TestDocs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	ginx.GET(engine.Group("/doc"), nil, "/hello", func(ctx context.Context) (string, error) {
+		return "hello", nil
+	})
+	openapi.Docs(engine, "/docs", ginx.OpenAPIInfo{Title: "test"})
+
+	t.Run("文档", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+		var doc struct {
+			Paths map[string]map[string]json.RawMessage `json:"paths"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil || len(doc.Paths["/doc/hello"]) != 1 {
+			t.Errorf("paths = %v, err = %v", doc.Paths, err)
+		}
+	})
+	t.Run("Swagger UI", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+			t.Errorf("got %d %s", w.Code, w.Header().Get("Content-Type"))
+		}
+		if strings.Contains(w.Body.String(), "https://") {
+			t.Errorf("page loads external resources: %s", w.Body.String())
+		}
+		for _, asset := range []string{"/docs/swagger-ui/swagger-ui.css", "/docs/swagger-ui/swagger-ui-bundle.js"} {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, asset, nil))
+			if w.Code != http.StatusOK || w.Body.Len() == 0 {
+				t.Errorf("GET %s = %d", asset, w.Code)
+			}
+		}
+	})
+}
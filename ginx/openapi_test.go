@@ -0,0 +1,110 @@
+package ginx_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/discover"
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/gin-gonic/gin"
+)
+
+type orderReq struct {
+	ID    string `path:"id"`
+	Page  int    `query:"page" validate:"required"`
+	Token string `header:"X-Token"`
+	Note  string `json:"note" validate:"required"`
+}
+
+type orderRes struct {
+	ID    string   `json:"id"`
+	Items []string `json:"items"`
+}
+
+func TestDocs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	group := engine.Group("/doc")
+	ginx.Handle(group, http.MethodPut, "/orders/:id", func(ctx context.Context, req *orderReq) (*orderRes, error) {
+		return &orderRes{}, nil
+	})
+	ginx.Proto(group, nil, http.MethodGet, "/meta/{version}", "", func(ctx context.Context, in *discover.Metadata) (*discover.Metadata, error) {
+		return in, nil
+	})
+	data, err := json.Marshal(ginx.OpenAPI(engine, ginx.OpenAPIInfo{Title: "test"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name     string `json:"name"`
+				In       string `json:"in"`
+				Required bool   `json:"required"`
+			} `json:"parameters"`
+			RequestBody *struct {
+				Content map[string]struct {
+					Schema struct {
+						Required []string `json:"required"`
+					} `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+		ErrorCodes []struct {
+			Code string `json:"code"`
+		} `json:"x-error-codes"`
+	}
+	if err = json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid document: %v, %s", err, data)
+	}
+
+	t.Run("结构体参数", func(t *testing.T) {
+		op, ok := doc.Paths["/doc/orders/{id}"]["put"]
+		if !ok {
+			t.Fatalf("operation not found: %v", doc.Paths)
+		}
+		params := map[string]bool{}
+		for _, p := range op.Parameters {
+			params[p.In+":"+p.Name] = p.Required
+		}
+		want := map[string]bool{"path:id": true, "query:page": true, "header:X-Token": false}
+		for k, required := range want {
+			if got, ok := params[k]; !ok || got != required {
+				t.Errorf("param %s: got %v (exists %v), want required %v", k, got, ok, required)
+			}
+		}
+		if op.RequestBody == nil || len(op.RequestBody.Content["application/json"].Schema.Required) != 1 {
+			t.Errorf("request body: %+v", op.RequestBody)
+		}
+		if _, ok := doc.Components.Schemas["ginx_test.orderRes"]; !ok {
+			t.Errorf("response schema not found: %v", doc.Components.Schemas)
+		}
+	})
+	t.Run("proto路由", func(t *testing.T) {
+		op, ok := doc.Paths["/doc/meta/{version}"]["get"]
+		if !ok {
+			t.Fatalf("operation not found: %v", doc.Paths)
+		}
+		if len(op.Parameters) < 2 || op.Parameters[0].Name != "version" {
+			t.Errorf("parameters: %+v", op.Parameters)
+		}
+		if _, ok := doc.Components.Schemas["discover.Metadata"]; !ok {
+			t.Errorf("message schema not found: %v", doc.Components.Schemas)
+		}
+	})
+	t.Run("错误码", func(t *testing.T) {
+		found := false
+		for _, e := range doc.ErrorCodes {
+			found = found || e.Code == "E002"
+		}
+		if !found {
+			t.Errorf("error codes: %+v", doc.ErrorCodes)
+		}
+	})
+}
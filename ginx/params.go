@@ -31,7 +31,12 @@ type BodyParam[T any] struct{}
 
 func (b *BodyParam[T]) GetParam(ctx *gin.Context) (res any, err error) {
 	res = new(T)
-	err = ctx.ShouldBindBodyWithJSON(res)
+	if err = ctx.ShouldBindBodyWithJSON(res); err != nil {
+		return nil, paramError(err)
+	}
+	if err = Validate(res); err != nil {
+		return nil, err
+	}
 	return
 }
 
@@ -61,7 +66,10 @@ type QueryParamItem struct {
 
 func (b *QueryParamItem) GetParam(ctx *gin.Context) (res any, err error) {
 	value := ctx.Query(b.Key)
-	return strConvert(value, b.Type)
+	if res, err = strConvert(value, b.Type); err != nil {
+		return nil, invalidParam(b.Key, err)
+	}
+	return
 }
 
 // Path 参数
@@ -76,7 +84,10 @@ type PathParamItem struct {
 
 func (b *PathParamItem) GetParam(ctx *gin.Context) (res any, err error) {
 	value := ctx.Param(b.Key)
-	return strConvert(value, b.Type)
+	if res, err = strConvert(value, b.Type); err != nil {
+		return nil, invalidParam(b.Key, err)
+	}
+	return
 }
 
 type Param interface {
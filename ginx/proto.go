@@ -0,0 +1,188 @@
+package ginx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// 路径模板中的变量，如 /v1/users/{id}、/v1/{user.id}
+var pathVar = regexp.MustCompile(`\{([\w.]+)\}`)
+
+// Proto 注册以proto消息为出入参的路由，供 protoc-gen-gmicor 生成的 Router 使用
+// path 为 google.api.http 的路径模板，变量只支持 {field} 形式，不支持 :verb 后缀
+// body 为 "*" 时请求体解码到整个消息，为字段名时解码到该字段，为空时不读请求体
+// 未被路径和请求体绑定的字段从 query 参数中读取
+// 请求与响应都使用 protojson 编解码，响应由 BasicMiddleware 统一包装
+func Proto[Req, Res proto.Message](group gin.IRouter, mid []HandlerFunc, method, path, body string, fun func(ctx context.Context, req Req) (Res, error)) {
+	ginPath, params := convertPath(path)
+	handler := func(ctx *gin.Context) {
+		var zero Req
+		req := zero.ProtoReflect().Type().New().Interface().(Req)
+		if err := bindProto(ctx, req, body, params); err != nil {
+			ctx.Set(ContextFuncResult, []any{nil, paramError(err)})
+			return
+		}
+		res, err := fun(ctx.Request.Context(), req)
+		if err != nil {
+			ctx.Set(ContextFuncResult, []any{nil, err})
+			return
+		}
+		data, err := protojson.Marshal(res)
+		if err != nil {
+			ctx.Set(ContextFuncResult, []any{nil, err})
+			return
+		}
+		ctx.Set(ContextFuncResult, []any{json.RawMessage(data), nil})
+	}
+	group.Handle(method, ginPath, append(handlerConvert(mid), handler)...)
+	var zero Req
+	addRoute(group, method, ginPath, reflect.TypeFor[Res](), []documenter{
+		&protoRequest{desc: zero.ProtoReflect().Descriptor(), body: body, params: params},
+	})
+}
+
+// convertPath 将路径模板转为 gin 路径，返回 gin 参数名到字段路径的映射
+func convertPath(path string) (string, map[string]string) {
+	params := make(map[string]string)
+	ginPath := pathVar.ReplaceAllStringFunc(path, func(s string) string {
+		field := s[1 : len(s)-1]
+		name := strings.ReplaceAll(field, ".", "_")
+		params[name] = field
+		return ":" + name
+	})
+	if strings.ContainsAny(pathVar.ReplaceAllString(path, ""), "{}:*") {
+		panic(fmt.Errorf("unsupported path template: %s", path))
+	}
+	return ginPath, params
+}
+
+// bindProto 依次绑定请求体、query 参数和路径参数
+func bindProto(ctx *gin.Context, msg proto.Message, body string, params map[string]string) error {
+	if body != "" {
+		data, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			return err
+		}
+		if len(data) > 0 {
+			if body != "*" {
+				// 解码到指定字段
+				fd := msg.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(body))
+				if fd == nil {
+					return fmt.Errorf("body field not found: %s", body)
+				}
+				data = []byte(`{"` + fd.JSONName() + `":` + string(data) + `}`)
+			}
+			if err = protojson.Unmarshal(data, msg); err != nil {
+				return err
+			}
+		}
+	}
+	if body != "*" {
+		for key, values := range ctx.Request.URL.Query() {
+			// 忽略与消息无关的参数
+			if err := setField(msg.ProtoReflect(), key, values); err != nil && !errors.Is(err, errFieldNotFound) {
+				return err
+			}
+		}
+	}
+	for name, field := range params {
+		if err := setField(msg.ProtoReflect(), field, []string{ctx.Param(name)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var errFieldNotFound = errors.New("field not found")
+
+// setField 按字段路径（如 user.id）设置字段值，字段名支持proto名和json名
+func setField(msg protoreflect.Message, path string, values []string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fields := msg.Descriptor().Fields()
+		fd := fields.ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = fields.ByJSONName(name)
+		}
+		if fd == nil {
+			return errFieldNotFound
+		}
+		if i < len(names)-1 {
+			if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+				return fmt.Errorf("invalid field path: %s", path)
+			}
+			msg = msg.Mutable(fd).Message()
+			continue
+		}
+		if fd.IsMap() || (!fd.IsList() && fd.Kind() == protoreflect.MessageKind) {
+			return fmt.Errorf("unsupported field type: %s", path)
+		}
+		if fd.IsList() {
+			list := msg.Mutable(fd).List()
+			for _, value := range values {
+				v, err := parseScalar(fd, value)
+				if err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+				list.Append(v)
+			}
+			return nil
+		}
+		v, err := parseScalar(fd, values[len(values)-1])
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		msg.Set(fd, v)
+	}
+	return nil
+}
+
+// parseScalar 将字符串解析为字段对应类型的值
+func parseScalar(fd protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(value)), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(value)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		v, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), err
+	}
+	return protoreflect.Value{}, ErrDataType
+}
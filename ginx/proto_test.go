@@ -0,0 +1,52 @@
+package ginx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/discover"
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/gin-gonic/gin"
+)
+
+func TestProto(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	echo := func(ctx context.Context, in *discover.Metadata) (*discover.Metadata, error) {
+		return in, nil
+	}
+	ginx.Proto(engine, nil, http.MethodGet, "/v1/meta/{version}", "", echo)
+	ginx.Proto(engine, nil, http.MethodPost, "/v1/meta/{zone}/tags", "tags", echo)
+	ginx.Proto(engine, nil, http.MethodPost, "/v1/meta", "*", echo)
+
+	serve := func(method, target, body string) string {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return w.Body.String()
+	}
+	t.Run("路径和query参数", func(t *testing.T) {
+		got := serve(http.MethodGet, "/v1/meta/v2?weight=3&tags=a&tags=b&unknown=1", "")
+		want := `{"code":"S000","data":{"version":"v2","weight":3,"tags":["a","b"]}}`
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+	t.Run("请求体绑定到字段", func(t *testing.T) {
+		got := serve(http.MethodPost, "/v1/meta/z1/tags", `["x"]`)
+		want := `{"code":"S000","data":{"zone":"z1","tags":["x"]}}`
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+	t.Run("请求体绑定到整个消息", func(t *testing.T) {
+		got := serve(http.MethodPost, "/v1/meta", `{"startTime":"12","zone":"z1"}`)
+		want := `{"code":"S000","data":{"zone":"z1","startTime":"12"}}`
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+}
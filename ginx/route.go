@@ -0,0 +1,84 @@
+package ginx
+
+import (
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route 通过 ginx 注册的路由，用于生成 OpenAPI 文档
+type Route struct {
+	Method   string       // HTTP 方法，Any 注册的路由为 ANY
+	Path     string       // 完整路径，gin 格式，如 /user/:id
+	Response reflect.Type // 返回数据的类型，无返回数据时为 nil
+
+	params []documenter
+}
+
+// routes 按注册顺序记录，同一方法和路径重复注册时保留最后一次
+// 生成文档时按 gin.Engine.Routes() 筛选，同一进程中多次创建引擎时文档互不影响
+var (
+	routeLock sync.Mutex
+	routes    []Route
+)
+
+// Routes engine 上通过 ginx 注册的路由
+func Routes(engine *gin.Engine) []Route {
+	registered := make(map[string]bool)
+	for _, info := range engine.Routes() {
+		registered[info.Method+" "+info.Path] = true
+		// Any 注册的路由在 gin 中展开为各个方法
+		registered["ANY "+info.Path] = true
+	}
+	routeLock.Lock()
+	defer routeLock.Unlock()
+	var res []Route
+	for _, route := range routes {
+		if registered[route.Method+" "+route.Path] {
+			res = append(res, route)
+		}
+	}
+	return res
+}
+
+// addRoute 记录路由，params 中实现了 documenter 的参数会出现在文档中
+func addRoute[P any](group gin.IRouter, method, relativePath string, response reflect.Type, params []P) {
+	route := Route{Method: method, Path: fullPath(group, relativePath), Response: response}
+	for _, param := range params {
+		if d, ok := any(param).(documenter); ok {
+			route.params = append(route.params, d)
+		}
+	}
+	routeLock.Lock()
+	defer routeLock.Unlock()
+	routes = slices.DeleteFunc(routes, func(r Route) bool {
+		return r.Method == route.Method && r.Path == route.Path
+	})
+	routes = append(routes, route)
+}
+
+// fullPath 拼接路由组的前缀
+func fullPath(group gin.IRouter, relativePath string) string {
+	base, ok := group.(interface{ BasePath() string })
+	if !ok || relativePath == "" {
+		return relativePath
+	}
+	res := path.Join(base.BasePath(), relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(res, "/") {
+		res += "/"
+	}
+	return res
+}
+
+// funcResponse 处理函数第一个返回值的类型，返回值不是 (data, error) 形式时为 nil
+func funcResponse(fun any) reflect.Type {
+	t := reflect.TypeOf(fun)
+	if t.Kind() != reflect.Func || t.NumOut() != 2 {
+		return nil
+	}
+	return t.Out(0)
+}
@@ -0,0 +1,44 @@
+package ginx
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Use 为路由组添加中间件，如 ginx.Use(group, ginx.Timeout(3*time.Second))
+func Use(group gin.IRoutes, mid ...HandlerFunc) {
+	group.Use(handlerConvert(mid)...)
+}
+
+// MidTimeOut 请求超时控制，见 Timeout
+func MidTimeOut(seconds int) HandlerFunc {
+	return Timeout(time.Duration(seconds) * time.Second)
+}
+
+// Timeout 请求超时控制，可用于单个路由或通过 Use 用于路由组，嵌套时以先到的截止时间为准
+// 截止时间通过请求的 context 传递给下游的 rpc、数据库调用，处理函数需使用该 context 才能及时返回；
+// 超时后无论处理函数返回什么，结果统一替换为 ErrTimeout。处理函数在同一协程中执行，不会并发写响应
+func Timeout(timeout time.Duration) HandlerFunc {
+	return func(ctx *gin.Context) error {
+		reqCtx, cancel := context.WithTimeout(ctx.Request.Context(), timeout)
+		defer cancel()
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		start := time.Now()
+		ctx.Next()
+		if !errors.Is(reqCtx.Err(), context.DeadlineExceeded) {
+			return nil
+		}
+		slog.WarnContext(reqCtx, "request timeout",
+			"path", ctx.FullPath(),
+			"method", ctx.Request.Method,
+			"timeout", timeout,
+			"cost", time.Since(start))
+		// 处理函数未使用 context 时可能在超时后返回成功，结果同样替换，已写出的响应不受影响
+		ctx.Set(ContextFuncResult, []any{nil, ErrTimeout})
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package ginx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/gin-gonic/gin"
+)
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	group := engine.Group("/slow")
+	ginx.Use(group, ginx.Timeout(time.Second))
+	// 等待截止时间，模拟下游调用超时
+	wait := func(ctx context.Context) (string, error) {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(5 * time.Second):
+			return "done", nil
+		}
+	}
+	ginx.GET(group, []ginx.HandlerFunc{ginx.Timeout(20 * time.Millisecond)}, "/route", wait)
+	// 不使用 context，超时后仍返回成功
+	ginx.GET(group, []ginx.HandlerFunc{ginx.Timeout(20 * time.Millisecond)}, "/ignore", func(ctx context.Context) (string, error) {
+		time.Sleep(50 * time.Millisecond)
+		return "done", nil
+	})
+	ginx.GET(group, nil, "/deadline", func(ctx context.Context) (bool, error) {
+		deadline, ok := ctx.Deadline()
+		return ok && time.Until(deadline) <= time.Second, nil
+	})
+
+	serve := func(path string) string {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Body.String()
+	}
+	t.Run("超时返回统一错误", func(t *testing.T) {
+		want := `{"model":"system","code":"E003","msg":"request timeout"}`
+		if got := serve("/slow/route"); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+	t.Run("处理函数忽略超时仍返回统一错误", func(t *testing.T) {
+		want := `{"model":"system","code":"E003","msg":"request timeout"}`
+		if got := serve("/slow/ignore"); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+	t.Run("路由组的截止时间传入处理函数", func(t *testing.T) {
+		want := `{"code":"S000","data":true}`
+		if got := serve("/slow/deadline"); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+}
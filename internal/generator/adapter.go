@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"path"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateAdapter 生成 localAdapter_gen.go
+// InitRPC 将服务注册到 grpc，并把 contract 中的 Client 替换为直接调用服务端实现的本地适配器
+// 本地适配器经 rpcx.DefaultChain 中的拦截器调用服务端实现，与经 grpc 调用时行为一致
+func generateAdapter(gen *protogen.Plugin, file *protogen.File, conf Config) {
+	g := gen.NewGeneratedFile(path.Join(conf.EndpointDir, "localAdapter_gen.go"), conf.EndpointImport)
+	g.P("// Code generated by protoc-gen-gmicor. DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", path.Base(string(conf.EndpointImport)))
+	g.P()
+
+	ss := services(file)
+	g.P("func InitRPC(register ", grpcPackage.Ident("ServiceRegistrar"), ") {")
+	for _, s := range ss {
+		g.P(s.serverVar(), " := &", s.serverType(), "{}")
+		g.P(file.GoImportPath.Ident(s.clientRef()), " = &", s.adapterType(), "{server: ", s.serverVar(), "} // 本地直接调")
+		g.P(file.GoImportPath.Ident("Register"+s.GoName+"Server"), "(register, ", s.serverVar(), ") // 将服务注册")
+	}
+	g.P("}")
+
+	for _, s := range ss {
+		g.P()
+		g.P("type ", s.adapterType(), " struct {")
+		g.P("server *", s.serverType())
+		g.P("}")
+		for _, m := range s.Methods {
+			g.P()
+			g.P("func (s *", s.adapterType(), ") ", m.GoName, clientSignature(g, m), " {")
+			method := g.QualifiedGoIdent(file.GoImportPath.Ident(s.GoName + "_" + m.GoName + "_FullMethodName"))
+			switch {
+			case m.Desc.IsStreamingClient() && m.Desc.IsStreamingServer():
+				g.P("return ", rpcxPackage.Ident("BidiStream"), "(ctx, ", method, ", s.server, s.server.", m.GoName, ")")
+			case m.Desc.IsStreamingClient():
+				g.P("return ", rpcxPackage.Ident("ClientStream"), "(ctx, ", method, ", s.server, s.server.", m.GoName, ")")
+			case m.Desc.IsStreamingServer():
+				g.P("return ", rpcxPackage.Ident("ServerStream"), "(ctx, ", method, ", s.server, in, s.server.", m.GoName, ")")
+			default:
+				g.P("return ", rpcxPackage.Ident("Unary"), "(ctx, ", method, ", s.server, in, s.server.", m.GoName, ")")
+			}
+			g.P("}")
+		}
+	}
+}
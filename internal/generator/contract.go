@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateContract 生成 contract_gen.go
+// Client 默认为通过注册中心调用的远程客户端，模块在本进程内启动时由 InitRPC 替换为本地调用
+func generateContract(gen *protogen.Plugin, file *protogen.File) {
+	g := gen.NewGeneratedFile(contractFilename(file), file.GoImportPath)
+	g.P("// Code generated by protoc-gen-gmicor. DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", file.GoPackageName)
+	g.P()
+	g.P("var ModuleName = ", `"`, moduleName(file), `"`)
+	g.P()
+
+	ss := services(file)
+	if len(ss) == 1 {
+		s := ss[0]
+		g.P(s.Comments.Leading, "var Client ", s.GoName, "Client = &", s.remoteType(), "{}")
+	} else {
+		g.P("// Client 模块内各服务的客户端")
+		g.P("var Client = struct {")
+		for _, s := range ss {
+			g.P(s.Comments.Leading, s.GoName, " ", s.GoName, "Client")
+		}
+		g.P("}{")
+		for _, s := range ss {
+			g.P(s.GoName, ": &", s.remoteType(), "{},")
+		}
+		g.P("}")
+	}
+
+	for _, s := range ss {
+		generateRemoteClient(g, s)
+	}
+}
+
+// generateRemoteClient 生成基于注册中心的远程客户端，首次调用时建立连接
+func generateRemoteClient(g *protogen.GeneratedFile, s service) {
+	g.P()
+	g.P("type ", s.remoteType(), " struct {")
+	g.P("client ", s.GoName, "Client")
+	g.P("}")
+	g.P()
+	g.P("func (s *", s.remoteType(), ") init() error {")
+	g.P("c, err := ", discoverPackage.Ident("Grpc"), "(ModuleName)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("s.client = New", s.GoName, "Client(c)")
+	g.P(slogPackage.Ident("Info"), `("`, moduleName(s.file), ` remote client init", "service", "`, s.GoName, `")`)
+	g.P("return nil")
+	g.P("}")
+	for _, m := range s.Methods {
+		g.P()
+		g.P(m.Comments.Leading, "func (s *", s.remoteType(), ") ", m.GoName, clientSignature(g, m), " {")
+		g.P("if s.client == nil {")
+		g.P("if err := s.init(); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("}")
+		g.P("return s.client.", m.GoName, "(", callArgs(m), ")")
+		g.P("}")
+	}
+}
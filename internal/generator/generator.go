@@ -0,0 +1,186 @@
+// Package generator 根据proto描述生成模块代码，供 protoc-gen-gmicor 插件和 gmicor 命令共用
+//
+// 对每个proto文件生成：
+//   - contract_gen.go    与 pb.go 同包，包含 ModuleName、Client 及基于注册中心的远程客户端
+//   - mock_gen.go        与 pb.go 同包，各服务客户端的测试替身 XxxMock
+//   - localAdapter_gen.go endpoint 包内，InitRPC 注册服务并将 Client 替换为本地直接调用
+//   - router_gen.go      endpoint 包内，Router 按 google.api.http 注解注册 HTTP 路由
+//   - rpcServer.go       endpoint 包内，服务端实现的骨架，只补充缺失的方法，不覆盖已有代码
+//
+// 文件只有一个 service 时保持 Client、RPCServer 的命名；
+// 多个 service 时 Client 为各服务客户端组成的结构体，服务端实现命名为 <Service>RPCServer
+package generator
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const (
+	contextPackage  = protogen.GoImportPath("context")
+	slogPackage     = protogen.GoImportPath("log/slog")
+	grpcPackage     = protogen.GoImportPath("google.golang.org/grpc")
+	discoverPackage = protogen.GoImportPath("github.com/Gong-Yang/g-micor/discover")
+	rpcxPackage     = protogen.GoImportPath("github.com/Gong-Yang/g-micor/rpcx")
+	ginxPackage     = protogen.GoImportPath("github.com/Gong-Yang/g-micor/ginx")
+	ginPackage      = protogen.GoImportPath("github.com/gin-gonic/gin")
+	httpPackage     = protogen.GoImportPath("net/http")
+)
+
+// Config 生成配置
+type Config struct {
+	// EndpointDir endpoint 包的输出目录，相对输出根目录，为空时只生成 contract_gen.go
+	EndpointDir string
+	// EndpointImport endpoint 包的导入路径
+	EndpointImport protogen.GoImportPath
+}
+
+// Run protoc-gen-gmicor 插件的入口，为请求中需要生成的文件生成代码
+func Run(gen *protogen.Plugin, conf Config) error {
+	if conf.EndpointImport == "" {
+		conf.EndpointDir = ""
+	} else if conf.EndpointDir == "" {
+		return errors.New("endpoint_dir is required when endpoint is set")
+	}
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	for _, f := range gen.Files {
+		if f.Generate {
+			Generate(gen, f, conf)
+		}
+	}
+	return nil
+}
+
+// Generate 为proto文件生成 contract_gen.go、mock_gen.go、localAdapter_gen.go 和 router_gen.go
+func Generate(gen *protogen.Plugin, file *protogen.File, conf Config) {
+	if len(file.Services) == 0 {
+		return
+	}
+	generateContract(gen, file)
+	generateMock(gen, file)
+	if conf.EndpointDir != "" {
+		generateAdapter(gen, file, conf)
+		generateRouter(gen, file, conf)
+	}
+}
+
+// moduleName 模块名，即 contract 包名去掉 C 后缀，如 userC -> user
+func moduleName(file *protogen.File) string {
+	return strings.TrimSuffix(string(file.GoPackageName), "C")
+}
+
+// contractFilename 与 pb.go 同目录的 contract_gen.go
+func contractFilename(file *protogen.File) string {
+	return path.Join(path.Dir(file.GeneratedFilenamePrefix), "contract_gen.go")
+}
+
+// service 带命名规则的 service
+type service struct {
+	*protogen.Service
+	file   *protogen.File
+	single bool // 文件中只有这一个 service
+}
+
+func services(file *protogen.File) []service {
+	ss := make([]service, len(file.Services))
+	for i, s := range file.Services {
+		ss[i] = service{Service: s, file: file, single: len(file.Services) == 1}
+	}
+	return ss
+}
+
+// serverType 服务端实现的类型名
+func (s service) serverType() string {
+	if s.single {
+		return "RPCServer"
+	}
+	return s.GoName + "RPCServer"
+}
+
+// remoteType 远程客户端的类型名
+func (s service) remoteType() string {
+	if s.single {
+		return moduleName(s.file) + "RemoteClient"
+	}
+	return lowerFirst(s.GoName) + "RemoteClient"
+}
+
+// adapterType 本地调用适配器的类型名
+func (s service) adapterType() string {
+	if s.single {
+		return "localAdapter"
+	}
+	return lowerFirst(s.GoName) + "LocalAdapter"
+}
+
+// clientRef 引用该服务客户端的表达式，如 Client、Client.Order
+func (s service) clientRef() string {
+	if s.single {
+		return "Client"
+	}
+	return "Client." + s.GoName
+}
+
+// serverVar InitRPC 中服务端实例的变量名
+func (s service) serverVar() string {
+	if s.single {
+		return "s"
+	}
+	return lowerFirst(s.GoName) + "Server"
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// clientSignature 客户端方法的参数和返回值，与 protoc-gen-go-grpc 生成的 XxxClient 一致
+func clientSignature(g *protogen.GeneratedFile, m *protogen.Method) string {
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	callOption := g.QualifiedGoIdent(grpcPackage.Ident("CallOption"))
+	in := g.QualifiedGoIdent(m.Input.GoIdent)
+	out := g.QualifiedGoIdent(m.Output.GoIdent)
+	switch {
+	case m.Desc.IsStreamingClient() && m.Desc.IsStreamingServer():
+		return "(ctx " + ctx + ", opts ..." + callOption + ") (" + g.QualifiedGoIdent(grpcPackage.Ident("BidiStreamingClient")) + "[" + in + ", " + out + "], error)"
+	case m.Desc.IsStreamingClient():
+		return "(ctx " + ctx + ", opts ..." + callOption + ") (" + g.QualifiedGoIdent(grpcPackage.Ident("ClientStreamingClient")) + "[" + in + ", " + out + "], error)"
+	case m.Desc.IsStreamingServer():
+		return "(ctx " + ctx + ", in *" + in + ", opts ..." + callOption + ") (" + g.QualifiedGoIdent(grpcPackage.Ident("ServerStreamingClient")) + "[" + out + "], error)"
+	default:
+		return "(ctx " + ctx + ", in *" + in + ", opts ..." + callOption + ") (*" + out + ", error)"
+	}
+}
+
+// serverSignature 服务端方法的参数和返回值，与 protoc-gen-go-grpc 生成的 XxxServer 一致
+func serverSignature(g *protogen.GeneratedFile, m *protogen.Method) string {
+	in := g.QualifiedGoIdent(m.Input.GoIdent)
+	out := g.QualifiedGoIdent(m.Output.GoIdent)
+	switch {
+	case m.Desc.IsStreamingClient() && m.Desc.IsStreamingServer():
+		return "(stream " + g.QualifiedGoIdent(grpcPackage.Ident("BidiStreamingServer")) + "[" + in + ", " + out + "]) error"
+	case m.Desc.IsStreamingClient():
+		return "(stream " + g.QualifiedGoIdent(grpcPackage.Ident("ClientStreamingServer")) + "[" + in + ", " + out + "]) error"
+	case m.Desc.IsStreamingServer():
+		return "(req *" + in + ", stream " + g.QualifiedGoIdent(grpcPackage.Ident("ServerStreamingServer")) + "[" + out + "]) error"
+	default:
+		return "(ctx " + g.QualifiedGoIdent(contextPackage.Ident("Context")) + ", req *" + in + ") (*" + out + ", error)"
+	}
+}
+
+// callArgs 客户端方法转调时的实参
+func callArgs(m *protogen.Method) string {
+	if m.Desc.IsStreamingClient() {
+		return "ctx, opts..."
+	}
+	return "ctx, in, opts..."
+}
@@ -0,0 +1,199 @@
+package generator_test
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/internal/generator"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// update 为 true 时用生成结果覆盖 testdata/golden
+var update = flag.Bool("update", false, "更新 testdata/golden 中的生成结果")
+
+// goldenImport testdata/golden 的导入路径，pb.go 由 protoc-gen-go、protoc-gen-go-grpc 预先生成
+const goldenImport = "github.com/Gong-Yang/g-micor/internal/generator/testdata/golden"
+
+// fixture testdata 中的proto描述及生成参数
+type fixture struct {
+	name        string // testdata/<name>.textproto
+	endpointDir string
+}
+
+var fixtures = []fixture{
+	{"user", "module/user/endpoint"}, // 一元、服务端流、客户端流、双向流，HTTP 注解
+	{"shop", "module/shop/endpoint"}, // 多个 service，additional_bindings
+}
+
+// request 读取proto描述，构造 protoc 传给插件的请求
+func request(t *testing.T, name, param string) *pluginpb.CodeGeneratorRequest {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name+".textproto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := &descriptorpb.FileDescriptorProto{}
+	if err = prototext.Unmarshal(data, file); err != nil {
+		t.Fatal(err)
+	}
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{file.GetName()},
+		Parameter:      proto.String(param),
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+			protodesc.ToFileDescriptorProto(annotations.File_google_api_http_proto),
+			protodesc.ToFileDescriptorProto(annotations.File_google_api_annotations_proto),
+			file,
+		},
+	}
+}
+
+// runPlugin 以 protoc 插件的方式执行 protogen.Options.Run，返回插件的输出
+func runPlugin(t *testing.T, req *pluginpb.CodeGeneratorRequest) *pluginpb.CodeGeneratorResponse {
+	t.Helper()
+	in, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	if _, err = stdin.Write(in); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = stdin.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+
+	// 插件拒绝命令行参数，执行期间去掉 go test 的参数
+	oldArgs, oldStdin, oldStdout := os.Args, os.Stdin, os.Stdout
+	os.Args, os.Stdin, os.Stdout = os.Args[:1], stdin, stdout
+	defer func() { os.Args, os.Stdin, os.Stdout = oldArgs, oldStdin, oldStdout }()
+
+	// 与 cmd/protoc-gen-gmicor 相同的参数解析
+	var flags flag.FlagSet
+	endpoint := flags.String("endpoint", "", "")
+	endpointDir := flags.String("endpoint_dir", "", "")
+	protogen.Options{ParamFunc: flags.Set}.Run(func(gen *protogen.Plugin) error {
+		return generator.Run(gen, generator.Config{
+			EndpointDir:    *endpointDir,
+			EndpointImport: protogen.GoImportPath(*endpoint),
+		})
+	})
+
+	out, err := os.ReadFile(stdout.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := &pluginpb.CodeGeneratorResponse{}
+	if err = proto.Unmarshal(out, res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+// stubs 生成 endpoint 包的 rpcServer.go，与 gmicor gen 在 endpoint 包为空时的输出一致
+func stubs(t *testing.T, name string, conf generator.Config) []byte {
+	t.Helper()
+	req := request(t, name, "paths=source_relative")
+	plugin, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := plugin.FilesByPath[req.FileToGenerate[0]]
+	content, err := generator.Stubs(plugin, file, conf, generator.Existing{}).Content()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+// compareGolden 比较生成结果和 testdata/golden 中的文件，-update 时覆盖
+func compareGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", filepath.FromSlash(name))
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v，使用 go test -update 生成", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s 与 golden 不一致，确认改动后使用 go test -update 更新\n--- got\n%s", name, got)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	for _, f := range fixtures {
+		t.Run(f.name, func(t *testing.T) {
+			conf := generator.Config{
+				EndpointDir:    f.endpointDir,
+				EndpointImport: protogen.GoImportPath(goldenImport + "/" + f.endpointDir),
+			}
+			param := "paths=source_relative,endpoint=" + string(conf.EndpointImport) + ",endpoint_dir=" + conf.EndpointDir
+			res := runPlugin(t, request(t, f.name, param))
+			if res.Error != nil {
+				t.Fatalf("插件返回错误: %s", res.GetError())
+			}
+			if len(res.File) == 0 {
+				t.Fatal("插件没有输出文件")
+			}
+			for _, file := range res.File {
+				t.Run(file.GetName(), func(t *testing.T) {
+					compareGolden(t, file.GetName(), []byte(file.GetContent()))
+				})
+			}
+			stubName := f.endpointDir + "/" + generator.StubFilename
+			t.Run(stubName, func(t *testing.T) {
+				compareGolden(t, stubName, stubs(t, f.name, conf))
+			})
+		})
+	}
+}
+
+func TestRunEndpointDirRequired(t *testing.T) {
+	res := runPlugin(t, request(t, "user", "paths=source_relative,endpoint="+goldenImport+"/module/user/endpoint"))
+	if res.GetError() == "" {
+		t.Error("设置 endpoint 未设置 endpoint_dir 时应返回错误")
+	}
+}
+
+// TestGoldenCompile 生成的代码能与 pb.go、rpcx.Mock、ginx.Proto 一起编译
+func TestGoldenCompile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("跳过编译检查")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("未找到 go 命令")
+	}
+	out, err := exec.Command(goBin, "vet", "./testdata/golden/...").CombinedOutput()
+	if err != nil {
+		t.Fatalf("golden 编译失败: %v\n%s", err, out)
+	}
+}
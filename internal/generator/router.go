@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+// route 一个方法对应的 HTTP 路由
+type route struct {
+	method string
+	path   string
+	body   string
+}
+
+// generateRouter 生成 router_gen.go
+// Router 按 google.api.http 注解将方法注册为 gin 路由，未注解的方法默认为 POST /<module>/<Method>
+// 多个 service 时默认路径为 POST /<module>/<Service>/<Method>，流式方法不生成路由
+func generateRouter(gen *protogen.Plugin, file *protogen.File, conf Config) {
+	g := gen.NewGeneratedFile(path.Join(conf.EndpointDir, "router_gen.go"), conf.EndpointImport)
+	g.P("// Code generated by protoc-gen-gmicor. DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", path.Base(string(conf.EndpointImport)))
+	g.P()
+	g.P("// Router 将 service 方法注册为 HTTP 路由，请求与响应使用 protojson 编解码")
+	g.P("func Router(router ", ginPackage.Ident("IRouter"), ", mid ...", ginxPackage.Ident("HandlerFunc"), ") {")
+	for _, s := range services(file) {
+		for _, m := range s.Methods {
+			if m.Desc.IsStreamingClient() || m.Desc.IsStreamingServer() {
+				continue
+			}
+			for _, r := range routes(s, m) {
+				g.P(ginxPackage.Ident("Proto"), "(router, mid, ", httpMethod(g, r.method), ", ", strconv.Quote(r.path), ", ", strconv.Quote(r.body), ", ",
+					"func(ctx ", contextPackage.Ident("Context"), ", in *", m.Input.GoIdent, ") (*", m.Output.GoIdent, ", error) {")
+				g.P("return ", file.GoImportPath.Ident(s.clientRef()), ".", m.GoName, "(ctx, in)")
+				g.P("})")
+			}
+		}
+	}
+	g.P("}")
+}
+
+// routes 方法的路由，包括 additional_bindings
+func routes(s service, m *protogen.Method) []route {
+	rule, _ := proto.GetExtension(m.Desc.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if r, ok := httpRoute(rule); ok {
+		res := []route{r}
+		for _, binding := range rule.GetAdditionalBindings() {
+			if r, ok = httpRoute(binding); ok {
+				res = append(res, r)
+			}
+		}
+		return res
+	}
+	p := "/" + moduleName(s.file) + "/" + string(m.Desc.Name())
+	if !s.single {
+		p = "/" + moduleName(s.file) + "/" + string(s.Desc.Name()) + "/" + string(m.Desc.Name())
+	}
+	return []route{{method: http.MethodPost, path: p, body: "*"}}
+}
+
+func httpRoute(rule *annotations.HttpRule) (route, bool) {
+	r := route{body: rule.GetBody()}
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		r.method, r.path = http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Put:
+		r.method, r.path = http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Post:
+		r.method, r.path = http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Delete:
+		r.method, r.path = http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		r.method, r.path = http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Custom:
+		r.method, r.path = pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return r, false
+	}
+	return r, r.path != ""
+}
+
+// httpMethod 标准方法引用 net/http 中的常量
+func httpMethod(g *protogen.GeneratedFile, method string) string {
+	switch method {
+	case http.MethodGet:
+		return g.QualifiedGoIdent(httpPackage.Ident("MethodGet"))
+	case http.MethodPut:
+		return g.QualifiedGoIdent(httpPackage.Ident("MethodPut"))
+	case http.MethodPost:
+		return g.QualifiedGoIdent(httpPackage.Ident("MethodPost"))
+	case http.MethodDelete:
+		return g.QualifiedGoIdent(httpPackage.Ident("MethodDelete"))
+	case http.MethodPatch:
+		return g.QualifiedGoIdent(httpPackage.Ident("MethodPatch"))
+	}
+	return strconv.Quote(method)
+}
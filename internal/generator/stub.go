@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"path"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// StubFilename 服务端实现骨架所在的文件名
+const StubFilename = "rpcServer.go"
+
+// Existing endpoint 包中已有的声明
+type Existing struct {
+	Types   map[string]bool            // 已声明的类型
+	Methods map[string]map[string]bool // 接收者类型 -> 已实现的方法
+}
+
+// Stubs 生成 endpoint 包中缺失的服务端类型和方法，没有缺失时返回 nil
+// 返回的文件已 Skip，不会出现在插件输出中，由调用方写入或合并到 rpcServer.go
+func Stubs(gen *protogen.Plugin, file *protogen.File, conf Config, existing Existing) *protogen.GeneratedFile {
+	g := gen.NewGeneratedFile(path.Join(conf.EndpointDir, StubFilename), conf.EndpointImport)
+	g.Skip()
+	g.P("package ", path.Base(string(conf.EndpointImport)))
+
+	missing := false
+	for _, s := range services(file) {
+		if !existing.Types[s.serverType()] {
+			missing = true
+			g.P()
+			g.P("type ", s.serverType(), " struct {")
+			g.P(file.GoImportPath.Ident("Unimplemented" + s.GoName + "Server"))
+			g.P("}")
+		}
+		for _, m := range s.Methods {
+			if existing.Methods[s.serverType()][m.GoName] {
+				continue
+			}
+			missing = true
+			g.P()
+			g.P("func (s *", s.serverType(), ") ", m.GoName, serverSignature(g, m), " {")
+			g.P(`panic("implement me")`)
+			g.P("}")
+		}
+	}
+	if !missing {
+		return nil
+	}
+	return g
+}
@@ -0,0 +1,77 @@
+// Code generated by protoc-gen-gmicor. DO NOT EDIT.
+// source: contract/shopC/shop.proto
+
+package shopC
+
+import (
+	context "context"
+	discover "github.com/Gong-Yang/g-micor/discover"
+	grpc "google.golang.org/grpc"
+	slog "log/slog"
+)
+
+var ModuleName = "shop"
+
+// Client 模块内各服务的客户端
+var Client = struct {
+	Order OrderClient
+	Cart  CartClient
+}{
+	Order: &orderRemoteClient{},
+	Cart:  &cartRemoteClient{},
+}
+
+type orderRemoteClient struct {
+	client OrderClient
+}
+
+func (s *orderRemoteClient) init() error {
+	c, err := discover.Grpc(ModuleName)
+	if err != nil {
+		return err
+	}
+	s.client = NewOrderClient(c)
+	slog.Info("shop remote client init", "service", "Order")
+	return nil
+}
+
+func (s *orderRemoteClient) Get(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (*OrderRes, error) {
+	if s.client == nil {
+		if err := s.init(); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.Get(ctx, in, opts...)
+}
+
+func (s *orderRemoteClient) Subscribe(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderRes], error) {
+	if s.client == nil {
+		if err := s.init(); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.Subscribe(ctx, in, opts...)
+}
+
+type cartRemoteClient struct {
+	client CartClient
+}
+
+func (s *cartRemoteClient) init() error {
+	c, err := discover.Grpc(ModuleName)
+	if err != nil {
+		return err
+	}
+	s.client = NewCartClient(c)
+	slog.Info("shop remote client init", "service", "Cart")
+	return nil
+}
+
+func (s *cartRemoteClient) Add(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (*OrderRes, error) {
+	if s.client == nil {
+		if err := s.init(); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.Add(ctx, in, opts...)
+}
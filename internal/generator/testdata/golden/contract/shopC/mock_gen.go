@@ -0,0 +1,98 @@
+// Code generated by protoc-gen-gmicor. DO NOT EDIT.
+// source: contract/shopC/shop.proto
+
+package shopC
+
+import (
+	context "context"
+	rpcx "github.com/Gong-Yang/g-micor/rpcx"
+	grpc "google.golang.org/grpc"
+)
+
+// OrderMock Order 服务客户端的测试替身
+// 方法转调对应的 XxxFunc，未设置时返回 codes.Unimplemented
+type OrderMock struct {
+	GetFunc       func(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (*OrderRes, error)
+	SubscribeFunc func(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderRes], error)
+
+	calls rpcx.MockCalls
+}
+
+var _ OrderClient = (*OrderMock)(nil)
+
+func (m *OrderMock) Get(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (*OrderRes, error) {
+	m.calls.Record("Get", in)
+	if m.GetFunc == nil {
+		return nil, rpcx.NotMocked("Order.Get")
+	}
+	return m.GetFunc(ctx, in, opts...)
+}
+
+// GetCalls Get 每次被调用时的请求
+func (m *OrderMock) GetCalls() []*OrderReq {
+	return rpcx.Calls[*OrderReq](&m.calls, "Get")
+}
+
+func (m *OrderMock) Subscribe(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderRes], error) {
+	m.calls.Record("Subscribe", in)
+	if m.SubscribeFunc == nil {
+		return nil, rpcx.NotMocked("Order.Subscribe")
+	}
+	return m.SubscribeFunc(ctx, in, opts...)
+}
+
+// SubscribeCalls Subscribe 每次被调用时的请求
+func (m *OrderMock) SubscribeCalls() []*OrderReq {
+	return rpcx.Calls[*OrderReq](&m.calls, "Subscribe")
+}
+
+// CallCount 方法被调用的次数
+func (m *OrderMock) CallCount(method string) int {
+	return m.calls.Count(method)
+}
+
+// Install 将 Client.Order 替换为 m，返回恢复原值的函数，可直接传给 t.Cleanup
+func (m *OrderMock) Install() (restore func()) {
+	old := Client.Order
+	Client.Order = m
+	return func() {
+		Client.Order = old
+	}
+}
+
+// CartMock Cart 服务客户端的测试替身
+// 方法转调对应的 XxxFunc，未设置时返回 codes.Unimplemented
+type CartMock struct {
+	AddFunc func(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (*OrderRes, error)
+
+	calls rpcx.MockCalls
+}
+
+var _ CartClient = (*CartMock)(nil)
+
+func (m *CartMock) Add(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (*OrderRes, error) {
+	m.calls.Record("Add", in)
+	if m.AddFunc == nil {
+		return nil, rpcx.NotMocked("Cart.Add")
+	}
+	return m.AddFunc(ctx, in, opts...)
+}
+
+// AddCalls Add 每次被调用时的请求
+func (m *CartMock) AddCalls() []*OrderReq {
+	return rpcx.Calls[*OrderReq](&m.calls, "Add")
+}
+
+// CallCount 方法被调用的次数
+func (m *CartMock) CallCount(method string) int {
+	return m.calls.Count(method)
+}
+
+// Install 将 Client.Cart 替换为 m，返回恢复原值的函数，可直接传给 t.Cleanup
+func (m *CartMock) Install() (restore func()) {
+	old := Client.Cart
+	Client.Cart = m
+	return func() {
+		Client.Cart = old
+	}
+}
@@ -0,0 +1,190 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: contract/shopC/shop.proto
+
+package shopC
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrderReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderReq) Reset() {
+	*x = OrderReq{}
+	mi := &file_contract_shopC_shop_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderReq) ProtoMessage() {}
+
+func (x *OrderReq) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_shopC_shop_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderReq.ProtoReflect.Descriptor instead.
+func (*OrderReq) Descriptor() ([]byte, []int) {
+	return file_contract_shopC_shop_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrderReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type OrderRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderRes) Reset() {
+	*x = OrderRes{}
+	mi := &file_contract_shopC_shop_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderRes) ProtoMessage() {}
+
+func (x *OrderRes) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_shopC_shop_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderRes.ProtoReflect.Descriptor instead.
+func (*OrderRes) Descriptor() ([]byte, []int) {
+	return file_contract_shopC_shop_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderRes) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderRes) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+var File_contract_shopC_shop_proto protoreflect.FileDescriptor
+
+const file_contract_shopC_shop_proto_rawDesc = "" +
+	"\n" +
+	"\x19contract/shopC/shop.proto\x12\x04shop\x1a\x1cgoogle/api/annotations.proto\"\x1a\n" +
+	"\bOrderReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\bOrderRes\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount2\x8f\x01\n" +
+	"\x05Order\x12W\n" +
+	"\x03Get\x12\x0e.shop.OrderReq\x1a\x0e.shop.OrderRes\"0\x82\xd3\xe4\x93\x02*Z\x15:\x01*\"\x10/shop/orders/get\x12\x11/shop/orders/{id}\x12-\n" +
+	"\tSubscribe\x12\x0e.shop.OrderReq\x1a\x0e.shop.OrderRes0\x012-\n" +
+	"\x04Cart\x12%\n" +
+	"\x03Add\x12\x0e.shop.OrderReq\x1a\x0e.shop.OrderResBPZNgithub.com/Gong-Yang/g-micor/internal/generator/testdata/golden/contract/shopCb\x06proto3"
+
+var (
+	file_contract_shopC_shop_proto_rawDescOnce sync.Once
+	file_contract_shopC_shop_proto_rawDescData []byte
+)
+
+func file_contract_shopC_shop_proto_rawDescGZIP() []byte {
+	file_contract_shopC_shop_proto_rawDescOnce.Do(func() {
+		file_contract_shopC_shop_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contract_shopC_shop_proto_rawDesc), len(file_contract_shopC_shop_proto_rawDesc)))
+	})
+	return file_contract_shopC_shop_proto_rawDescData
+}
+
+var file_contract_shopC_shop_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_contract_shopC_shop_proto_goTypes = []any{
+	(*OrderReq)(nil), // 0: shop.OrderReq
+	(*OrderRes)(nil), // 1: shop.OrderRes
+}
+var file_contract_shopC_shop_proto_depIdxs = []int32{
+	0, // 0: shop.Order.Get:input_type -> shop.OrderReq
+	0, // 1: shop.Order.Subscribe:input_type -> shop.OrderReq
+	0, // 2: shop.Cart.Add:input_type -> shop.OrderReq
+	1, // 3: shop.Order.Get:output_type -> shop.OrderRes
+	1, // 4: shop.Order.Subscribe:output_type -> shop.OrderRes
+	1, // 5: shop.Cart.Add:output_type -> shop.OrderRes
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_contract_shopC_shop_proto_init() }
+func file_contract_shopC_shop_proto_init() {
+	if File_contract_shopC_shop_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contract_shopC_shop_proto_rawDesc), len(file_contract_shopC_shop_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_contract_shopC_shop_proto_goTypes,
+		DependencyIndexes: file_contract_shopC_shop_proto_depIdxs,
+		MessageInfos:      file_contract_shopC_shop_proto_msgTypes,
+	}.Build()
+	File_contract_shopC_shop_proto = out.File
+	file_contract_shopC_shop_proto_goTypes = nil
+	file_contract_shopC_shop_proto_depIdxs = nil
+}
@@ -0,0 +1,76 @@
+// Code generated by protoc-gen-gmicor. DO NOT EDIT.
+// source: contract/userC/user.proto
+
+package userC
+
+import (
+	context "context"
+	discover "github.com/Gong-Yang/g-micor/discover"
+	grpc "google.golang.org/grpc"
+	slog "log/slog"
+)
+
+var ModuleName = "user"
+
+// User 用户服务
+var Client UserClient = &userRemoteClient{}
+
+type userRemoteClient struct {
+	client UserClient
+}
+
+func (s *userRemoteClient) init() error {
+	c, err := discover.Grpc(ModuleName)
+	if err != nil {
+		return err
+	}
+	s.client = NewUserClient(c)
+	slog.Info("user remote client init", "service", "User")
+	return nil
+}
+
+// Hello 问候
+func (s *userRemoteClient) Hello(ctx context.Context, in *HelloReq, opts ...grpc.CallOption) (*HelloRes, error) {
+	if s.client == nil {
+		if err := s.init(); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.Hello(ctx, in, opts...)
+}
+
+func (s *userRemoteClient) Create(ctx context.Context, in *HelloReq, opts ...grpc.CallOption) (*HelloRes, error) {
+	if s.client == nil {
+		if err := s.init(); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.Create(ctx, in, opts...)
+}
+
+func (s *userRemoteClient) Watch(ctx context.Context, in *HelloReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HelloRes], error) {
+	if s.client == nil {
+		if err := s.init(); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.Watch(ctx, in, opts...)
+}
+
+func (s *userRemoteClient) Upload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HelloReq, HelloRes], error) {
+	if s.client == nil {
+		if err := s.init(); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.Upload(ctx, opts...)
+}
+
+func (s *userRemoteClient) Chat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HelloReq, HelloRes], error) {
+	if s.client == nil {
+		if err := s.init(); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.Chat(ctx, opts...)
+}
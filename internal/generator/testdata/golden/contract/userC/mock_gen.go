@@ -0,0 +1,93 @@
+// Code generated by protoc-gen-gmicor. DO NOT EDIT.
+// source: contract/userC/user.proto
+
+package userC
+
+import (
+	context "context"
+	rpcx "github.com/Gong-Yang/g-micor/rpcx"
+	grpc "google.golang.org/grpc"
+)
+
+// UserMock User 服务客户端的测试替身
+// 方法转调对应的 XxxFunc，未设置时返回 codes.Unimplemented
+type UserMock struct {
+	HelloFunc  func(ctx context.Context, in *HelloReq, opts ...grpc.CallOption) (*HelloRes, error)
+	CreateFunc func(ctx context.Context, in *HelloReq, opts ...grpc.CallOption) (*HelloRes, error)
+	WatchFunc  func(ctx context.Context, in *HelloReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HelloRes], error)
+	UploadFunc func(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HelloReq, HelloRes], error)
+	ChatFunc   func(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HelloReq, HelloRes], error)
+
+	calls rpcx.MockCalls
+}
+
+var _ UserClient = (*UserMock)(nil)
+
+func (m *UserMock) Hello(ctx context.Context, in *HelloReq, opts ...grpc.CallOption) (*HelloRes, error) {
+	m.calls.Record("Hello", in)
+	if m.HelloFunc == nil {
+		return nil, rpcx.NotMocked("User.Hello")
+	}
+	return m.HelloFunc(ctx, in, opts...)
+}
+
+// HelloCalls Hello 每次被调用时的请求
+func (m *UserMock) HelloCalls() []*HelloReq {
+	return rpcx.Calls[*HelloReq](&m.calls, "Hello")
+}
+
+func (m *UserMock) Create(ctx context.Context, in *HelloReq, opts ...grpc.CallOption) (*HelloRes, error) {
+	m.calls.Record("Create", in)
+	if m.CreateFunc == nil {
+		return nil, rpcx.NotMocked("User.Create")
+	}
+	return m.CreateFunc(ctx, in, opts...)
+}
+
+// CreateCalls Create 每次被调用时的请求
+func (m *UserMock) CreateCalls() []*HelloReq {
+	return rpcx.Calls[*HelloReq](&m.calls, "Create")
+}
+
+func (m *UserMock) Watch(ctx context.Context, in *HelloReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HelloRes], error) {
+	m.calls.Record("Watch", in)
+	if m.WatchFunc == nil {
+		return nil, rpcx.NotMocked("User.Watch")
+	}
+	return m.WatchFunc(ctx, in, opts...)
+}
+
+// WatchCalls Watch 每次被调用时的请求
+func (m *UserMock) WatchCalls() []*HelloReq {
+	return rpcx.Calls[*HelloReq](&m.calls, "Watch")
+}
+
+func (m *UserMock) Upload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HelloReq, HelloRes], error) {
+	m.calls.Record("Upload", nil)
+	if m.UploadFunc == nil {
+		return nil, rpcx.NotMocked("User.Upload")
+	}
+	return m.UploadFunc(ctx, opts...)
+}
+
+func (m *UserMock) Chat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HelloReq, HelloRes], error) {
+	m.calls.Record("Chat", nil)
+	if m.ChatFunc == nil {
+		return nil, rpcx.NotMocked("User.Chat")
+	}
+	return m.ChatFunc(ctx, opts...)
+}
+
+// CallCount 方法被调用的次数
+func (m *UserMock) CallCount(method string) int {
+	return m.calls.Count(method)
+}
+
+// Install 将 Client 替换为 m，返回恢复原值的函数，可直接传给 t.Cleanup
+func (m *UserMock) Install() (restore func()) {
+	old := Client
+	Client = m
+	return func() {
+		Client = old
+	}
+}
@@ -0,0 +1,186 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: contract/userC/user.proto
+
+package userC
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type HelloReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HelloReq) Reset() {
+	*x = HelloReq{}
+	mi := &file_contract_userC_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HelloReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelloReq) ProtoMessage() {}
+
+func (x *HelloReq) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_userC_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelloReq.ProtoReflect.Descriptor instead.
+func (*HelloReq) Descriptor() ([]byte, []int) {
+	return file_contract_userC_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HelloReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type HelloRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Msg           string                 `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HelloRes) Reset() {
+	*x = HelloRes{}
+	mi := &file_contract_userC_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HelloRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelloRes) ProtoMessage() {}
+
+func (x *HelloRes) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_userC_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelloRes.ProtoReflect.Descriptor instead.
+func (*HelloRes) Descriptor() ([]byte, []int) {
+	return file_contract_userC_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HelloRes) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+var File_contract_userC_user_proto protoreflect.FileDescriptor
+
+const file_contract_userC_user_proto_rawDesc = "" +
+	"\n" +
+	"\x19contract/userC/user.proto\x12\x04user\x1a\x1cgoogle/api/annotations.proto\"\x1e\n" +
+	"\bHelloReq\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1c\n" +
+	"\bHelloRes\x12\x10\n" +
+	"\x03msg\x18\x01 \x01(\tR\x03msg2\xf8\x01\n" +
+	"\x04User\x12C\n" +
+	"\x05Hello\x12\x0e.user.HelloReq\x1a\x0e.user.HelloRes\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/user/hello/{name}\x12(\n" +
+	"\x06Create\x12\x0e.user.HelloReq\x1a\x0e.user.HelloRes\x12)\n" +
+	"\x05Watch\x12\x0e.user.HelloReq\x1a\x0e.user.HelloRes0\x01\x12*\n" +
+	"\x06Upload\x12\x0e.user.HelloReq\x1a\x0e.user.HelloRes(\x01\x12*\n" +
+	"\x04Chat\x12\x0e.user.HelloReq\x1a\x0e.user.HelloRes(\x010\x01BPZNgithub.com/Gong-Yang/g-micor/internal/generator/testdata/golden/contract/userCb\x06proto3"
+
+var (
+	file_contract_userC_user_proto_rawDescOnce sync.Once
+	file_contract_userC_user_proto_rawDescData []byte
+)
+
+func file_contract_userC_user_proto_rawDescGZIP() []byte {
+	file_contract_userC_user_proto_rawDescOnce.Do(func() {
+		file_contract_userC_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contract_userC_user_proto_rawDesc), len(file_contract_userC_user_proto_rawDesc)))
+	})
+	return file_contract_userC_user_proto_rawDescData
+}
+
+var file_contract_userC_user_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_contract_userC_user_proto_goTypes = []any{
+	(*HelloReq)(nil), // 0: user.HelloReq
+	(*HelloRes)(nil), // 1: user.HelloRes
+}
+var file_contract_userC_user_proto_depIdxs = []int32{
+	0, // 0: user.User.Hello:input_type -> user.HelloReq
+	0, // 1: user.User.Create:input_type -> user.HelloReq
+	0, // 2: user.User.Watch:input_type -> user.HelloReq
+	0, // 3: user.User.Upload:input_type -> user.HelloReq
+	0, // 4: user.User.Chat:input_type -> user.HelloReq
+	1, // 5: user.User.Hello:output_type -> user.HelloRes
+	1, // 6: user.User.Create:output_type -> user.HelloRes
+	1, // 7: user.User.Watch:output_type -> user.HelloRes
+	1, // 8: user.User.Upload:output_type -> user.HelloRes
+	1, // 9: user.User.Chat:output_type -> user.HelloRes
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_contract_userC_user_proto_init() }
+func file_contract_userC_user_proto_init() {
+	if File_contract_userC_user_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contract_userC_user_proto_rawDesc), len(file_contract_userC_user_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_contract_userC_user_proto_goTypes,
+		DependencyIndexes: file_contract_userC_user_proto_depIdxs,
+		MessageInfos:      file_contract_userC_user_proto_msgTypes,
+	}.Build()
+	File_contract_userC_user_proto = out.File
+	file_contract_userC_user_proto_goTypes = nil
+	file_contract_userC_user_proto_depIdxs = nil
+}
@@ -0,0 +1,40 @@
+// Code generated by protoc-gen-gmicor. DO NOT EDIT.
+// source: contract/shopC/shop.proto
+
+package endpoint
+
+import (
+	context "context"
+	shopC "github.com/Gong-Yang/g-micor/internal/generator/testdata/golden/contract/shopC"
+	rpcx "github.com/Gong-Yang/g-micor/rpcx"
+	grpc "google.golang.org/grpc"
+)
+
+func InitRPC(register grpc.ServiceRegistrar) {
+	orderServer := &OrderRPCServer{}
+	shopC.Client.Order = &orderLocalAdapter{server: orderServer} // 本地直接调
+	shopC.RegisterOrderServer(register, orderServer)             // 将服务注册
+	cartServer := &CartRPCServer{}
+	shopC.Client.Cart = &cartLocalAdapter{server: cartServer} // 本地直接调
+	shopC.RegisterCartServer(register, cartServer)            // 将服务注册
+}
+
+type orderLocalAdapter struct {
+	server *OrderRPCServer
+}
+
+func (s *orderLocalAdapter) Get(ctx context.Context, in *shopC.OrderReq, opts ...grpc.CallOption) (*shopC.OrderRes, error) {
+	return rpcx.Unary(ctx, shopC.Order_Get_FullMethodName, s.server, in, s.server.Get)
+}
+
+func (s *orderLocalAdapter) Subscribe(ctx context.Context, in *shopC.OrderReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[shopC.OrderRes], error) {
+	return rpcx.ServerStream(ctx, shopC.Order_Subscribe_FullMethodName, s.server, in, s.server.Subscribe)
+}
+
+type cartLocalAdapter struct {
+	server *CartRPCServer
+}
+
+func (s *cartLocalAdapter) Add(ctx context.Context, in *shopC.OrderReq, opts ...grpc.CallOption) (*shopC.OrderRes, error) {
+	return rpcx.Unary(ctx, shopC.Cart_Add_FullMethodName, s.server, in, s.server.Add)
+}
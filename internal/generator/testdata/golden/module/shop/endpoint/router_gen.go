@@ -0,0 +1,25 @@
+// Code generated by protoc-gen-gmicor. DO NOT EDIT.
+// source: contract/shopC/shop.proto
+
+package endpoint
+
+import (
+	context "context"
+	ginx "github.com/Gong-Yang/g-micor/ginx"
+	shopC "github.com/Gong-Yang/g-micor/internal/generator/testdata/golden/contract/shopC"
+	gin "github.com/gin-gonic/gin"
+	http "net/http"
+)
+
+// Router 将 service 方法注册为 HTTP 路由，请求与响应使用 protojson 编解码
+func Router(router gin.IRouter, mid ...ginx.HandlerFunc) {
+	ginx.Proto(router, mid, http.MethodGet, "/shop/orders/{id}", "", func(ctx context.Context, in *shopC.OrderReq) (*shopC.OrderRes, error) {
+		return shopC.Client.Order.Get(ctx, in)
+	})
+	ginx.Proto(router, mid, http.MethodPost, "/shop/orders/get", "*", func(ctx context.Context, in *shopC.OrderReq) (*shopC.OrderRes, error) {
+		return shopC.Client.Order.Get(ctx, in)
+	})
+	ginx.Proto(router, mid, http.MethodPost, "/shop/Cart/Add", "*", func(ctx context.Context, in *shopC.OrderReq) (*shopC.OrderRes, error) {
+		return shopC.Client.Cart.Add(ctx, in)
+	})
+}
@@ -0,0 +1,27 @@
+package endpoint
+
+import (
+	context "context"
+	shopC "github.com/Gong-Yang/g-micor/internal/generator/testdata/golden/contract/shopC"
+	grpc "google.golang.org/grpc"
+)
+
+type OrderRPCServer struct {
+	shopC.UnimplementedOrderServer
+}
+
+func (s *OrderRPCServer) Get(ctx context.Context, req *shopC.OrderReq) (*shopC.OrderRes, error) {
+	panic("implement me")
+}
+
+func (s *OrderRPCServer) Subscribe(req *shopC.OrderReq, stream grpc.ServerStreamingServer[shopC.OrderRes]) error {
+	panic("implement me")
+}
+
+type CartRPCServer struct {
+	shopC.UnimplementedCartServer
+}
+
+func (s *CartRPCServer) Add(ctx context.Context, req *shopC.OrderReq) (*shopC.OrderRes, error) {
+	panic("implement me")
+}
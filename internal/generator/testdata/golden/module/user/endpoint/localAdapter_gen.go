@@ -0,0 +1,41 @@
+// Code generated by protoc-gen-gmicor. DO NOT EDIT.
+// source: contract/userC/user.proto
+
+package endpoint
+
+import (
+	context "context"
+	userC "github.com/Gong-Yang/g-micor/internal/generator/testdata/golden/contract/userC"
+	rpcx "github.com/Gong-Yang/g-micor/rpcx"
+	grpc "google.golang.org/grpc"
+)
+
+func InitRPC(register grpc.ServiceRegistrar) {
+	s := &RPCServer{}
+	userC.Client = &localAdapter{server: s} // 本地直接调
+	userC.RegisterUserServer(register, s)   // 将服务注册
+}
+
+type localAdapter struct {
+	server *RPCServer
+}
+
+func (s *localAdapter) Hello(ctx context.Context, in *userC.HelloReq, opts ...grpc.CallOption) (*userC.HelloRes, error) {
+	return rpcx.Unary(ctx, userC.User_Hello_FullMethodName, s.server, in, s.server.Hello)
+}
+
+func (s *localAdapter) Create(ctx context.Context, in *userC.HelloReq, opts ...grpc.CallOption) (*userC.HelloRes, error) {
+	return rpcx.Unary(ctx, userC.User_Create_FullMethodName, s.server, in, s.server.Create)
+}
+
+func (s *localAdapter) Watch(ctx context.Context, in *userC.HelloReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[userC.HelloRes], error) {
+	return rpcx.ServerStream(ctx, userC.User_Watch_FullMethodName, s.server, in, s.server.Watch)
+}
+
+func (s *localAdapter) Upload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[userC.HelloReq, userC.HelloRes], error) {
+	return rpcx.ClientStream(ctx, userC.User_Upload_FullMethodName, s.server, s.server.Upload)
+}
+
+func (s *localAdapter) Chat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[userC.HelloReq, userC.HelloRes], error) {
+	return rpcx.BidiStream(ctx, userC.User_Chat_FullMethodName, s.server, s.server.Chat)
+}
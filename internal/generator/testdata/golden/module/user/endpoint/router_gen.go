@@ -0,0 +1,22 @@
+// Code generated by protoc-gen-gmicor. DO NOT EDIT.
+// source: contract/userC/user.proto
+
+package endpoint
+
+import (
+	context "context"
+	ginx "github.com/Gong-Yang/g-micor/ginx"
+	userC "github.com/Gong-Yang/g-micor/internal/generator/testdata/golden/contract/userC"
+	gin "github.com/gin-gonic/gin"
+	http "net/http"
+)
+
+// Router 将 service 方法注册为 HTTP 路由，请求与响应使用 protojson 编解码
+func Router(router gin.IRouter, mid ...ginx.HandlerFunc) {
+	ginx.Proto(router, mid, http.MethodGet, "/user/hello/{name}", "", func(ctx context.Context, in *userC.HelloReq) (*userC.HelloRes, error) {
+		return userC.Client.Hello(ctx, in)
+	})
+	ginx.Proto(router, mid, http.MethodPost, "/user/Create", "*", func(ctx context.Context, in *userC.HelloReq) (*userC.HelloRes, error) {
+		return userC.Client.Create(ctx, in)
+	})
+}
@@ -0,0 +1,31 @@
+package endpoint
+
+import (
+	context "context"
+	userC "github.com/Gong-Yang/g-micor/internal/generator/testdata/golden/contract/userC"
+	grpc "google.golang.org/grpc"
+)
+
+type RPCServer struct {
+	userC.UnimplementedUserServer
+}
+
+func (s *RPCServer) Hello(ctx context.Context, req *userC.HelloReq) (*userC.HelloRes, error) {
+	panic("implement me")
+}
+
+func (s *RPCServer) Create(ctx context.Context, req *userC.HelloReq) (*userC.HelloRes, error) {
+	panic("implement me")
+}
+
+func (s *RPCServer) Watch(req *userC.HelloReq, stream grpc.ServerStreamingServer[userC.HelloRes]) error {
+	panic("implement me")
+}
+
+func (s *RPCServer) Upload(stream grpc.ClientStreamingServer[userC.HelloReq, userC.HelloRes]) error {
+	panic("implement me")
+}
+
+func (s *RPCServer) Chat(stream grpc.BidiStreamingServer[userC.HelloReq, userC.HelloRes]) error {
+	panic("implement me")
+}
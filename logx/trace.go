@@ -0,0 +1,101 @@
+package logx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// TraceIDKey 追踪号在 context 和日志属性中的 key
+var TraceIDKey = "TraceID"
+
+// 传递追踪号的请求头，gRPC metadata 中使用小写形式
+const (
+	HeaderTraceparent = "traceparent"  // W3C Trace Context
+	HeaderRequestID   = "X-Request-Id" // 不支持 traceparent 的调用方使用
+)
+
+// NewTraceID 生成 32 位十六进制的追踪号，与 W3C trace-id 格式一致
+func NewTraceID() string {
+	return randomHex(16)
+}
+
+// WithTraceID 将追踪号放入 context，之后带该 context 的日志都会输出追踪号
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	ctx = context.WithValue(ctx, TraceIDKey, traceID)
+	return AddAttrs(ctx, TraceIDKey, traceID)
+}
+
+// TraceID context 中的追踪号，没有时返回空
+func TraceID(ctx context.Context) string {
+	traceID, _ := ctx.Value(TraceIDKey).(string)
+	return traceID
+}
+
+// IncomingTraceID 从 traceparent、X-Request-Id 中取得调用方的追踪号，优先使用 traceparent，都没有或不合法时返回空
+// X-Request-Id 只接受不超过 128 字节的字母、数字和 . _ -，避免向日志和响应头注入内容；
+// 开启 otel 时只有 32 位十六进制的请求号会作为 trace-id，其他格式的请求号在下一跳会被 traceparent 中的 trace-id 取代
+func IncomingTraceID(traceparent, requestID string) string {
+	if traceID := ParseTraceparent(traceparent); traceID != "" {
+		return traceID
+	}
+	requestID = strings.TrimSpace(requestID)
+	if !isRequestID(requestID) {
+		return ""
+	}
+	return requestID
+}
+
+// isRequestID 请求号是否只包含安全字符且不过长
+func isRequestID(s string) bool {
+	if len(s) == 0 || len(s) > 128 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && c != '.' && c != '_' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTraceparent 解析 traceparent，返回其中的 trace-id，格式不合法时返回空
+// 格式为 version-traceid-parentid-flags，如 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func ParseTraceparent(traceparent string) string {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return ""
+	}
+	if !isTraceID(parts[1]) || !isHex(parts[2]) || parts[2] == strings.Repeat("0", 16) {
+		return ""
+	}
+	return parts[1]
+}
+
+// Traceparent 以 traceID 生成新的 traceparent，traceID 不是 W3C trace-id 格式时返回空
+func Traceparent(traceID string) string {
+	if !isTraceID(traceID) {
+		return ""
+	}
+	return "00-" + traceID + "-" + randomHex(8) + "-01"
+}
+
+func isTraceID(s string) bool {
+	return len(s) == 32 && isHex(s) && s != strings.Repeat("0", 32)
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
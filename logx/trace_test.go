@@ -0,0 +1,57 @@
+package logx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	tests := []struct {
+		name        string
+		traceparent string
+		want        string
+	}{
+		{"合法", "00-" + traceID + "-00f067aa0ba902b7-01", traceID},
+		{"前后空白", " 00-" + traceID + "-00f067aa0ba902b7-01 ", traceID},
+		{"空", "", ""},
+		{"版本ff", "ff-" + traceID + "-00f067aa0ba902b7-01", ""},
+		{"trace-id全0", "00-" + strings.Repeat("0", 32) + "-00f067aa0ba902b7-01", ""},
+		{"trace-id大写", "00-" + strings.ToUpper(traceID) + "-00f067aa0ba902b7-01", ""},
+		{"parent-id全0", "00-" + traceID + "-0000000000000000-01", ""},
+		{"缺少flags", "00-" + traceID + "-00f067aa0ba902b7", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseTraceparent(tt.traceparent); got != tt.want {
+				t.Errorf("ParseTraceparent(%q) = %q, want %q", tt.traceparent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncomingTraceID(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	tests := []struct {
+		name        string
+		traceparent string
+		requestID   string
+		want        string
+	}{
+		{"优先traceparent", traceparent, "req-1", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"traceparent不合法时用请求号", "bad", "req-1", "req-1"},
+		{"请求号允许的字符", "", " order_1.A-2 ", "order_1.A-2"},
+		{"请求号包含空格", "", "req 1", ""},
+		{"请求号包含换行", "", "req\nfake=1", ""},
+		{"请求号包含非ASCII", "", "请求1", ""},
+		{"请求号过长", "", strings.Repeat("a", 129), ""},
+		{"都没有", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IncomingTraceID(tt.traceparent, tt.requestID); got != tt.want {
+				t.Errorf("IncomingTraceID(%q, %q) = %q, want %q", tt.traceparent, tt.requestID, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Package metricx Prometheus 指标
+//
+// 框架记录 HTTP 路由、gRPC 方法的请求数、耗时和错误码，以及连接池、MQ 消费组、线程池的状态，
+// 通过 Handler 以 Prometheus 文本格式暴露，app 在配置了 app.metrics 或 app.metricsPort 时挂载。
+// 业务指标通过 Register 注册到同一个 Registry。
+package metricx
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace 框架指标名的前缀
+const Namespace = "gmicor"
+
+// Registry 框架指标的注册表，包含 Go 运行时和进程指标
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP 请求数，code 为响应的错误码",
+	}, []string{"method", "route", "status", "code"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP 请求耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "rpc_requests_total",
+		Help:      "gRPC 调用数，side 为 server 或 client，code 为 grpc 状态码",
+	}, []string{"side", "method", "code"})
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "rpc_duration_seconds",
+		Help:      "gRPC 调用耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"side", "method"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration, rpcRequests, rpcDuration,
+	)
+}
+
+// Register 注册指标，重复注册时 panic
+func Register(cs ...prometheus.Collector) {
+	Registry.MustRegister(cs...)
+}
+
+// Handler 以 Prometheus 文本格式输出 Registry 中的指标
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ObserveHTTP 记录一次 HTTP 请求，route 为路由模板，未匹配路由时为空
+func ObserveHTTP(method, route string, status int, code string, d time.Duration) {
+	httpRequests.WithLabelValues(method, route, strconv.Itoa(status), code).Inc()
+	httpDuration.WithLabelValues(method, route).Observe(d.Seconds())
+}
+
+// ObserveRPC 记录一次 gRPC 调用，method 为完整方法名 /pkg.Service/Method
+func ObserveRPC(side, method, code string, d time.Duration) {
+	rpcRequests.WithLabelValues(side, method, code).Inc()
+	rpcDuration.WithLabelValues(side, method).Observe(d.Seconds())
+}
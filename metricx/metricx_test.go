@@ -0,0 +1,52 @@
+package metricx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/Gong-Yang/g-micor/metricx"
+	"github.com/Gong-Yang/g-micor/syncx"
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	ginx.GET(engine, nil, "/metric/ok", func(ctx context.Context) (string, error) {
+		return "ok", nil
+	})
+	ginx.GET(engine, nil, "/metric/fail", func(ctx context.Context) (string, error) {
+		return "", ginx.ErrAuthFail
+	})
+	engine.GET("/metrics", gin.WrapH(metricx.Handler()))
+	serve := func(path string) string {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Body.String()
+	}
+	serve("/metric/ok")
+	serve("/metric/fail")
+	serve("/metric/fail")
+	pool := syncx.NewNamedWorkerPool(context.Background(), "test", 2)
+	defer pool.Close()
+
+	body := serve("/metrics")
+	for _, want := range []string{
+		`gmicor_http_requests_total{code="S000",method="GET",route="/metric/ok",status="200"} 1`,
+		`gmicor_http_requests_total{code="system:E001",method="GET",route="/metric/fail",status="200"} 2`,
+		`gmicor_http_request_duration_seconds_count{method="GET",route="/metric/fail"} 2`,
+		`gmicor_worker_pool_size{pool="test"} 2`,
+		`go_goroutines`,
+	} {
+		t.Run(want, func(t *testing.T) {
+			if !strings.Contains(body, want) {
+				t.Errorf("指标缺少 %s\n%s", want, body)
+			}
+		})
+	}
+}
@@ -25,6 +25,8 @@ type Coll[T CollInterface] struct {
 
 func (t *Coll[T]) FindOne(ctx context.Context, filter interface{},
 	opts ...options.Lister[options.FindOneOptions]) (res *T, err error) {
+	ctx, op := t.trace(ctx, "FindOne")
+	defer func() { endTrace(op, err) }()
 	coll := getColl(ctx, t)
 	err = coll.FindOne(ctx, filter, opts...).Decode(&res)
 	return
@@ -32,12 +34,16 @@ func (t *Coll[T]) FindOne(ctx context.Context, filter interface{},
 
 func (t *Coll[T]) FindById(ctx context.Context, id interface{},
 	opts ...options.Lister[options.FindOneOptions]) (res *T, err error) {
+	ctx, op := t.trace(ctx, "FindById")
+	defer func() { endTrace(op, err) }()
 	coll := getColl(ctx, t)
 	err = coll.FindOne(ctx, bson.M{"_id": id}, opts...).Decode(&res)
 	return
 }
 func (t *Coll[T]) Find(ctx context.Context, filter interface{},
 	opts ...options.Lister[options.FindOptions]) (res []*T, err error) {
+	ctx, op := t.trace(ctx, "Find")
+	defer func() { endTrace(op, err) }()
 	coll := getColl(ctx, t)
 	cur, err := coll.Find(ctx, filter, opts...)
 	if err != nil {
@@ -48,6 +54,8 @@ func (t *Coll[T]) Find(ctx context.Context, filter interface{},
 }
 func (t *Coll[T]) Aggregate(ctx context.Context, pipeline []bson.M, res any,
 	opts ...options.Lister[options.AggregateOptions]) (err error) {
+	ctx, op := t.trace(ctx, "Aggregate")
+	defer func() { endTrace(op, err) }()
 	coll := getColl(ctx, t)
 	aggregate, err := coll.Aggregate(ctx, pipeline, opts...)
 	if err != nil {
@@ -56,7 +64,9 @@ func (t *Coll[T]) Aggregate(ctx context.Context, pipeline []bson.M, res any,
 	return aggregate.All(ctx, res)
 }
 func (t *Coll[T]) InsertOne(ctx context.Context, document interface{},
-	opts ...options.Lister[options.InsertOneOptions]) (*mongo.InsertOneResult, error) {
+	opts ...options.Lister[options.InsertOneOptions]) (result *mongo.InsertOneResult, err error) {
+	ctx, op := t.trace(ctx, "InsertOne")
+	defer func() { endTrace(op, err) }()
 	coll := getColl(ctx, t)
 	if c, ok := document.(CollInterface); ok {
 		c.Write(ctx)
@@ -64,7 +74,9 @@ func (t *Coll[T]) InsertOne(ctx context.Context, document interface{},
 	return coll.InsertOne(ctx, document, opts...)
 }
 func (t *Coll[T]) InsertMany(ctx context.Context, documents []CollInterface,
-	opts ...options.Lister[options.InsertManyOptions]) (*mongo.InsertManyResult, error) {
+	opts ...options.Lister[options.InsertManyOptions]) (result *mongo.InsertManyResult, err error) {
+	ctx, op := t.trace(ctx, "InsertMany")
+	defer func() { endTrace(op, err) }()
 	coll := getColl(ctx, t)
 	res := make([]interface{}, len(documents))
 	for i, document := range documents {
@@ -73,12 +85,16 @@ func (t *Coll[T]) InsertMany(ctx context.Context, documents []CollInterface,
 	}
 	return coll.InsertMany(ctx, res, opts...)
 }
-func (t *Coll[T]) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...options.Lister[options.UpdateOneOptions]) (*mongo.UpdateResult, error) {
+func (t *Coll[T]) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...options.Lister[options.UpdateOneOptions]) (result *mongo.UpdateResult, err error) {
+	ctx, op := t.trace(ctx, "UpdateOne")
+	defer func() { endTrace(op, err) }()
 	coll := getColl(ctx, t)
 	return coll.UpdateOne(ctx, filter, update, opts...)
 }
 func (t *Coll[T]) BulkWrite(ctx context.Context, models []mongo.WriteModel,
-	opts ...options.Lister[options.BulkWriteOptions]) (*mongo.BulkWriteResult, error) {
+	opts ...options.Lister[options.BulkWriteOptions]) (result *mongo.BulkWriteResult, err error) {
+	ctx, op := t.trace(ctx, "BulkWrite")
+	defer func() { endTrace(op, err) }()
 	coll := getColl(ctx, t)
 	return coll.BulkWrite(ctx, models, opts...)
 }
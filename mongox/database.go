@@ -2,6 +2,7 @@ package mongox
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -38,3 +39,19 @@ func InitDB(uri string, dbname string) error {
 
 	return nil
 }
+
+// Ping 检查与 MongoDB 的连接
+func Ping(ctx context.Context) error {
+	if db == nil {
+		return errors.New("mongox: not init")
+	}
+	return db.Client().Ping(ctx, nil)
+}
+
+// Close 断开 MongoDB 连接
+func Close(ctx context.Context) error {
+	if db == nil {
+		return nil
+	}
+	return db.Client().Disconnect(ctx)
+}
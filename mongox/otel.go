@@ -0,0 +1,29 @@
+package mongox
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gong-Yang/g-micor/otelx"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var dbInstrument = otelx.NewInstrument("db.client.operation.duration", "数据库操作耗时", trace.SpanKindClient)
+
+// trace 集合操作的埋点
+func (t *Coll[T]) trace(ctx context.Context, operation string) (context.Context, *otelx.Op) {
+	return dbInstrument.Start(ctx, operation+" "+t.CollName,
+		attribute.String("db.system.name", "mongodb"),
+		attribute.String("db.collection.name", t.CollName),
+		attribute.String("db.operation.name", operation))
+}
+
+// endTrace 结束集合操作，查询不到数据不视为错误
+func endTrace(op *otelx.Op, err error) {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = nil
+	}
+	op.End(err)
+}
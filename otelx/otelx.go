@@ -0,0 +1,92 @@
+// Package otelx OpenTelemetry 埋点
+//
+// 框架各组件通过 Instrument 记录 span 和耗时指标，未调用 Setup 或 Install 时使用 otel 的空实现，几乎没有开销。
+// app 在配置了 otel.endpoint 时通过 OTLP 导出，测试中可用 Install 接入内存导出器：
+//
+//	exporter := tracetest.NewInMemoryExporter()
+//	reader := sdkmetric.NewManualReader()
+//	shutdown := otelx.Install(sdktrace.NewSimpleSpanProcessor(exporter), reader, nil)
+package otelx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gong-Yang/g-micor/errorx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName 框架埋点的 instrumentation scope
+const ScopeName = "github.com/Gong-Yang/g-micor"
+
+// 全局 provider 设置前取得的 tracer、meter 会在设置后委托给新的 provider
+var (
+	tracer = otel.Tracer(ScopeName)
+	meter  = otel.Meter(ScopeName)
+)
+
+// Instrument 一类操作的埋点，每次操作记录一个 span 和一次耗时
+type Instrument struct {
+	kind     trace.SpanKind
+	duration metric.Float64Histogram
+}
+
+// NewInstrument 创建埋点，metricName 为耗时直方图的名字，单位为秒
+func NewInstrument(metricName, description string, kind trace.SpanKind) *Instrument {
+	duration, err := meter.Float64Histogram(metricName, metric.WithUnit("s"), metric.WithDescription(description))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return &Instrument{kind: kind, duration: duration}
+}
+
+// Start 开始一次操作，attrs 同时用于 span 和指标，只应包含取值有限的属性
+func (i *Instrument) Start(ctx context.Context, spanName string, attrs ...attribute.KeyValue) (context.Context, *Op) {
+	ctx, span := tracer.Start(ctx, spanName, trace.WithSpanKind(i.kind), trace.WithAttributes(attrs...))
+	return ctx, &Op{ctx: ctx, span: span, start: time.Now(), duration: i.duration, attrs: attrs}
+}
+
+// Op 进行中的一次操作
+type Op struct {
+	ctx      context.Context
+	span     trace.Span
+	start    time.Time
+	duration metric.Float64Histogram
+	attrs    []attribute.KeyValue
+}
+
+// Span 操作的 span，可添加只用于 span 的属性，如语句、消息ID
+func (o *Op) Span() trace.Span {
+	return o.span
+}
+
+// SetAttributes 添加同时用于 span 和指标的属性
+func (o *Op) SetAttributes(attrs ...attribute.KeyValue) {
+	o.span.SetAttributes(attrs...)
+	o.attrs = append(o.attrs, attrs...)
+}
+
+// End 结束操作，记录耗时
+// err 为 errorx.ErrorCode 时视为业务结果，只记录 error.type 而不将 span 标记为失败
+func (o *Op) End(err error) {
+	attrs := o.attrs
+	if err != nil {
+		var code errorx.ErrorCode
+		if errors.As(err, &code) {
+			attrs = append(attrs, attribute.String("error.type", code.Model+":"+code.Code))
+		} else {
+			attrs = append(attrs, attribute.String("error.type", fmt.Sprintf("%T", err)))
+			o.span.RecordError(err)
+			o.span.SetStatus(codes.Error, err.Error())
+		}
+		o.span.SetAttributes(attrs[len(attrs)-1])
+	}
+	o.duration.Record(o.ctx, time.Since(o.start).Seconds(), metric.WithAttributes(attrs...))
+	o.span.End()
+}
@@ -0,0 +1,128 @@
+package otelx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Gong-Yang/g-micor/ginx"
+	"github.com/Gong-Yang/g-micor/otelx"
+	"github.com/Gong-Yang/g-micor/rpcx"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeClientStream 每次读取都成功的客户端流
+type fakeClientStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *fakeClientStream) Context() context.Context { return s.ctx }
+func (s *fakeClientStream) SendMsg(m any) error      { return nil }
+func (s *fakeClientStream) CloseSend() error         { return nil }
+func (s *fakeClientStream) RecvMsg(m any) error      { return s.ctx.Err() }
+
+func fakeStreamer(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return &fakeClientStream{ctx: ctx}, nil
+}
+
+func TestInstall(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	shutdown := otelx.Install(sdktrace.NewSimpleSpanProcessor(exporter), reader, nil)
+	t.Cleanup(func() { shutdown(context.Background()) })
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ginx.BasicMiddleware)
+	ginx.GET(engine, nil, "/hello/:name", func(ctx context.Context) (string, error) {
+		// 本地调用其他模块
+		res, err := rpcx.Unary(ctx, "/test.Test/Hello", nil, wrapperspb.String("x"),
+			func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				return wrapperspb.String("hi " + in.Value), nil
+			})
+		return res.GetValue(), err
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hello/a", nil))
+	spans := exporter.GetSpans()
+
+	t.Run("span的父子关系", func(t *testing.T) {
+		if len(spans) != 2 {
+			t.Fatalf("got %d spans: %v", len(spans), spans)
+		}
+		rpc, web := spans[0], spans[1]
+		if web.Name != "GET /hello/:name" || rpc.Name != "test.Test/Hello" {
+			t.Errorf("span names: %q, %q", web.Name, rpc.Name)
+		}
+		if rpc.Parent.SpanID() != web.SpanContext.SpanID() {
+			t.Errorf("rpc span parent = %v, want %v", rpc.Parent.SpanID(), web.SpanContext.SpanID())
+		}
+	})
+	t.Run("trace-id与日志追踪号一致", func(t *testing.T) {
+		if got, want := spans[1].SpanContext.TraceID().String(), w.Header().Get("X-Request-Id"); got != want {
+			t.Errorf("trace id = %s, request id = %s", got, want)
+		}
+	})
+	t.Run("耗时指标", func(t *testing.T) {
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatal(err)
+		}
+		names := map[string]bool{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				names[m.Name] = true
+			}
+		}
+		for _, name := range []string{"http.server.request.duration", "rpc.server.duration"} {
+			if !names[name] {
+				t.Errorf("metric %s not found in %v", name, names)
+			}
+		}
+	})
+	t.Run("客户端流成功读取响应后结束span", func(t *testing.T) {
+		exporter.Reset()
+		desc := &grpc.StreamDesc{ClientStreams: true}
+		stream, err := rpcx.OtelStreamClient(context.Background(), desc, nil, "/test.Test/Upload", fakeStreamer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream.SendMsg(wrapperspb.String("a"))
+		stream.CloseSend()
+		if err = stream.RecvMsg(&wrapperspb.StringValue{}); err != nil {
+			t.Fatal(err)
+		}
+		spans := exporter.GetSpans()
+		if len(spans) != 1 || spans[0].Name != "test.Test/Upload" || spans[0].Status.Code == codes.Error {
+			t.Errorf("spans = %v", spans)
+		}
+	})
+	t.Run("调用方取消后结束span", func(t *testing.T) {
+		exporter.Reset()
+		ctx, cancel := context.WithCancel(context.Background())
+		desc := &grpc.StreamDesc{ServerStreams: true}
+		if _, err := rpcx.OtelStreamClient(ctx, desc, nil, "/test.Test/Watch", fakeStreamer); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+		deadline := time.Now().Add(time.Second)
+		for len(exporter.GetSpans()) == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		spans := exporter.GetSpans()
+		if len(spans) != 1 || spans[0].Status.Code != codes.Error {
+			t.Errorf("spans = %v", spans)
+		}
+	})
+}
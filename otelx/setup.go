@@ -0,0 +1,115 @@
+package otelx
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/Gong-Yang/g-micor/logx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config OTLP 导出配置
+type Config struct {
+	Endpoint       string        // OTLP gRPC 地址，如 otel-collector:4317
+	Insecure       bool          // 不使用 TLS
+	SampleRatio    float64       // 采样比例，0 到 1，默认 1；调用方已采样的请求始终采样
+	ExportInterval time.Duration // 指标导出间隔，默认 30 秒
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Setup 创建 OTLP 导出器并设置全局 provider，返回的 shutdown 在停机时调用以导出剩余数据
+func Setup(ctx context.Context, conf Config) (shutdown func(context.Context) error, err error) {
+	if conf.Endpoint == "" {
+		return nil, errors.New("otelx: empty endpoint")
+	}
+	traceOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(conf.Endpoint)}
+	metricOpts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(conf.Endpoint)}
+	if conf.Insecure {
+		traceOpts = append(traceOpts, otlptracegrpc.WithInsecure())
+		metricOpts = append(metricOpts, otlpmetricgrpc.WithInsecure())
+	}
+	traceExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+	if err != nil {
+		return nil, err
+	}
+	metricExporter, err := otlpmetricgrpc.New(ctx, metricOpts...)
+	if err != nil {
+		return nil, err
+	}
+	interval := conf.ExportInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ratio := conf.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	res := resource.NewSchemaless(
+		attribute.String("service.name", conf.ServiceName),
+		attribute.String("service.version", conf.ServiceVersion),
+	)
+	return Install(
+		sdktrace.NewBatchSpanProcessor(traceExporter),
+		sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(interval)),
+		res,
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	), nil
+}
+
+// Install 以 span 处理器和指标读取器设置全局 provider 和 W3C traceparent 传播，res 可为空
+// 新的根 span 使用 context 中 logx 的追踪号作为 trace-id，日志与链路可以互相关联
+func Install(processor sdktrace.SpanProcessor, reader sdkmetric.Reader, res *resource.Resource, opts ...sdktrace.TracerProviderOption) (shutdown func(context.Context) error) {
+	if res == nil {
+		res = resource.Default()
+	}
+	opts = append([]sdktrace.TracerProviderOption{
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(res),
+		sdktrace.WithIDGenerator(idGenerator{}),
+	}, opts...)
+	tp := sdktrace.NewTracerProvider(opts...)
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithResource(res))
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), mp.Shutdown(ctx))
+	}
+}
+
+// idGenerator 优先使用 logx 的追踪号作为 trace-id
+type idGenerator struct{}
+
+func (idGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	var traceID trace.TraceID
+	if b, err := hex.DecodeString(logx.TraceID(ctx)); err == nil && len(b) == len(traceID) {
+		copy(traceID[:], b)
+	}
+	if !traceID.IsValid() {
+		_, _ = crand.Read(traceID[:])
+	}
+	return traceID, newSpanID()
+}
+
+func (idGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	return newSpanID()
+}
+
+func newSpanID() (id trace.SpanID) {
+	for !id.IsValid() {
+		_, _ = crand.Read(id[:])
+	}
+	return
+}
@@ -0,0 +1,52 @@
+package pgsql
+
+import (
+	"github.com/Gong-Yang/g-micor/metricx"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	metricx.Register(poolCollector{})
+}
+
+var (
+	poolConns = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "pgsql", "pool_conns"),
+		"连接池连接数，state 为 acquired、idle、constructing", []string{"state"}, nil)
+	poolMaxConns = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "pgsql", "pool_max_conns"),
+		"连接池最大连接数", nil, nil)
+	poolAcquires = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "pgsql", "pool_acquires_total"),
+		"获取连接次数", nil, nil)
+	poolEmptyAcquires = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "pgsql", "pool_empty_acquires_total"),
+		"连接池为空需要等待的获取次数", nil, nil)
+	poolCanceledAcquires = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "pgsql", "pool_canceled_acquires_total"),
+		"等待中被取消的获取次数", nil, nil)
+	poolAcquireSeconds = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "pgsql", "pool_acquire_seconds_total"),
+		"获取连接的累计耗时", nil, nil)
+)
+
+// poolCollector 采集默认连接池的 Stat，未初始化时不输出
+type poolCollector struct{}
+
+func (poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolConns
+	ch <- poolMaxConns
+	ch <- poolAcquires
+	ch <- poolEmptyAcquires
+	ch <- poolCanceledAcquires
+	ch <- poolAcquireSeconds
+}
+
+func (poolCollector) Collect(ch chan<- prometheus.Metric) {
+	if PoolManager == nil || PoolManager.defaultPool == nil {
+		return
+	}
+	stat := PoolManager.defaultPool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolConns, prometheus.GaugeValue, float64(stat.AcquiredConns()), "acquired")
+	ch <- prometheus.MustNewConstMetric(poolConns, prometheus.GaugeValue, float64(stat.IdleConns()), "idle")
+	ch <- prometheus.MustNewConstMetric(poolConns, prometheus.GaugeValue, float64(stat.ConstructingConns()), "constructing")
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireSeconds, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
@@ -0,0 +1,29 @@
+package pgsql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gong-Yang/g-micor/otelx"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var dbInstrument = otelx.NewInstrument("db.client.operation.duration", "数据库操作耗时", trace.SpanKindClient)
+
+// trace 表操作的埋点
+func (t *Table[T]) trace(ctx context.Context, operation string) (context.Context, *otelx.Op) {
+	return dbInstrument.Start(ctx, operation+" "+t.name,
+		attribute.String("db.system.name", "postgresql"),
+		attribute.String("db.collection.name", t.name),
+		attribute.String("db.operation.name", operation))
+}
+
+// endTrace 结束表操作，查询不到数据不视为错误
+func endTrace(op *otelx.Op, err error) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	op.End(err)
+}
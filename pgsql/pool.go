@@ -2,6 +2,7 @@ package pgsql
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 
@@ -65,3 +66,19 @@ func (p *poolManager) newPool(configconnString string) (*pgxpool.Pool, error) {
 	}
 	return pool, nil
 }
+
+// Ping 从默认连接池获取连接并检查
+func Ping(ctx context.Context) error {
+	if PoolManager == nil || PoolManager.defaultPool == nil {
+		return errors.New("pgsql: not init")
+	}
+	return PoolManager.defaultPool.Ping(ctx)
+}
+
+// Close 关闭连接池，等待借出的连接归还
+func Close() {
+	if PoolManager == nil || PoolManager.defaultPool == nil {
+		return
+	}
+	PoolManager.defaultPool.Close()
+}
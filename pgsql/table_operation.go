@@ -10,6 +10,8 @@ import (
 
 // ID 提前生成ID
 func (t *Table[T]) ID(ctx context.Context) (id int64, err error) {
+	ctx, op := t.trace(ctx, "ID")
+	defer func() { endTrace(op, err) }()
 	pool, err := PoolManager.Get(ctx)
 	if err != nil {
 		return
@@ -19,7 +21,9 @@ func (t *Table[T]) ID(ctx context.Context) (id int64, err error) {
 	return
 }
 
-func (t *Table[T]) InsertOne(ctx context.Context, entity *T) error {
+func (t *Table[T]) InsertOne(ctx context.Context, entity *T) (err error) {
+	ctx, op := t.trace(ctx, "InsertOne")
+	defer func() { endTrace(op, err) }()
 	pool, err := PoolManager.Get(ctx)
 	if err != nil {
 		return err
@@ -50,7 +54,9 @@ func (t *Table[T]) InsertOne(ctx context.Context, entity *T) error {
 	reflect.ValueOf(entity).Elem().Field(t.pkField.Index).SetInt(returnedID)
 	return nil
 }
-func (t *Table[T]) InsertMany(ctx context.Context, entities []*T) error {
+func (t *Table[T]) InsertMany(ctx context.Context, entities []*T) (err error) {
+	ctx, op := t.trace(ctx, "InsertMany")
+	defer func() { endTrace(op, err) }()
 	if len(entities) == 0 {
 		return nil
 	}
@@ -129,7 +135,9 @@ func (t *Table[T]) insertManyBatch(ctx context.Context, entities []*T, includePK
 
 // ---- FindByID ----
 
-func (t *Table[T]) FindByID(ctx context.Context, id int64) (*T, error) {
+func (t *Table[T]) FindByID(ctx context.Context, id int64) (res *T, err error) {
+	ctx, op := t.trace(ctx, "FindByID")
+	defer func() { endTrace(op, err) }()
 	pool, err := PoolManager.Get(ctx)
 	if err != nil {
 		return nil, err
@@ -155,7 +163,9 @@ func (t *Table[T]) FindByID(ctx context.Context, id int64) (*T, error) {
 
 // ---- Find ----
 
-func (t *Table[T]) FindOne(ctx context.Context, wb *WhereBuilder) (*T, error) {
+func (t *Table[T]) FindOne(ctx context.Context, wb *WhereBuilder) (res *T, err error) {
+	ctx, op := t.trace(ctx, "FindOne")
+	defer func() { endTrace(op, err) }()
 	pool, err := PoolManager.Get(ctx)
 	if err != nil {
 		return nil, err
@@ -182,7 +192,9 @@ func (t *Table[T]) FindOne(ctx context.Context, wb *WhereBuilder) (*T, error) {
 
 // ---- Find ----
 
-func (t *Table[T]) Find(ctx context.Context, wb *WhereBuilder) ([]*T, error) {
+func (t *Table[T]) Find(ctx context.Context, wb *WhereBuilder) (res []*T, err error) {
+	ctx, op := t.trace(ctx, "Find")
+	defer func() { endTrace(op, err) }()
 	pool, err := PoolManager.Get(ctx)
 	if err != nil {
 		return nil, err
@@ -202,7 +214,9 @@ func (t *Table[T]) Find(ctx context.Context, wb *WhereBuilder) ([]*T, error) {
 
 	return t.scanRows(rows)
 }
-func (t *Table[T]) Count(ctx context.Context, wb *WhereBuilder) (int64, error) {
+func (t *Table[T]) Count(ctx context.Context, wb *WhereBuilder) (res int64, err error) {
+	ctx, op := t.trace(ctx, "Count")
+	defer func() { endTrace(op, err) }()
 	pool, err := PoolManager.Get(ctx)
 	if err != nil {
 		return 0, err
@@ -226,7 +240,9 @@ func (t *Table[T]) Count(ctx context.Context, wb *WhereBuilder) (int64, error) {
 
 // ---- FindPage ----
 
-func (t *Table[T]) FindPage(ctx context.Context, wb *WhereBuilder, page, pageSize int) (*Page[T], error) {
+func (t *Table[T]) FindPage(ctx context.Context, wb *WhereBuilder, page, pageSize int) (res *Page[T], err error) {
+	ctx, op := t.trace(ctx, "FindPage")
+	defer func() { endTrace(op, err) }()
 	if page < 1 {
 		page = 1
 	}
@@ -292,7 +308,9 @@ func (t *Table[T]) FindPage(ctx context.Context, wb *WhereBuilder, page, pageSiz
 
 // ---- UpdateByID ----
 
-func (t *Table[T]) UpdateByID(ctx context.Context, entity *T) error {
+func (t *Table[T]) UpdateByID(ctx context.Context, entity *T) (err error) {
+	ctx, op := t.trace(ctx, "UpdateByID")
+	defer func() { endTrace(op, err) }()
 	pool, err := PoolManager.Get(ctx)
 	if err != nil {
 		return err
@@ -343,7 +361,9 @@ func (t *Table[T]) UpdateByID(ctx context.Context, entity *T) error {
 
 // ---- Update ----
 
-func (t *Table[T]) Update(ctx context.Context, ub *UpdateBuilder, wb *WhereBuilder) (int64, error) {
+func (t *Table[T]) Update(ctx context.Context, ub *UpdateBuilder, wb *WhereBuilder) (res int64, err error) {
+	ctx, op := t.trace(ctx, "Update")
+	defer func() { endTrace(op, err) }()
 	if ub == nil || len(ub.sets) == 0 {
 		return 0, fmt.Errorf("Update: nothing to set")
 	}
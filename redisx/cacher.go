@@ -24,25 +24,32 @@ type CacherKey interface {
 }
 
 func (c *Cacher[T, K]) Get(ctx context.Context, key K) (T, error) {
+	ctx, op := traceCache(ctx, "get", c.prefix)
 	result := getEntity[T]()
 	cacheKey := c.prefix + ":" + key.GenKey()
 	err := GetProto(ctx, cacheKey, result)
+	endCache(op, true, err)
 	return result, err
 }
 
 func (c *Cacher[T, K]) Set(ctx context.Context, key K, value T) error {
+	ctx, op := traceCache(ctx, "set", c.prefix)
 	cacheKey := c.prefix + ":" + key.GenKey()
-	return SetPorto(ctx, cacheKey, value, c.expire)
+	err := SetPorto(ctx, cacheKey, value, c.expire)
+	endCache(op, false, err)
+	return err
 }
 
 // GetAndDelete 从Redis获取并反序列化JSON对象
 func (c *Cacher[T, K]) GetAndDelete(ctx context.Context, key K) (T, error) {
+	ctx, op := traceCache(ctx, "get_and_delete", c.prefix)
 	result := getEntity[T]()
 	cacheKey := c.prefix + ":" + key.GenKey()
 	err := GetProto(ctx, cacheKey, result)
 	if err == nil {
 		Client.Del(ctx, cacheKey)
 	}
+	endCache(op, true, err)
 	return result, err
 }
 
@@ -23,6 +23,14 @@ func Init(consumerName string, opt *redis.Options) {
 	InitSingleFlight()
 }
 
+// Close 关闭Redis连接
+func Close() error {
+	if Client == nil {
+		return nil
+	}
+	return Client.Close()
+}
+
 var initList []Initialize
 
 type Initialize interface {
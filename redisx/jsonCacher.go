@@ -21,26 +21,33 @@ func NewJSONCacher[T any, K CacherKey](prefix string, expire time.Duration) *JSO
 
 // Get 从Redis获取并反序列化JSON对象
 func (c *JSONCacher[T, K]) Get(ctx context.Context, key K) (T, error) {
+	ctx, op := traceCache(ctx, "get", c.prefix)
 	result := getEntity[T]()
 	cacheKey := c.prefix + ":" + key.GenKey()
 	err := GetJSON(ctx, cacheKey, &result)
+	endCache(op, true, err)
 	return result, err
 }
 
 // Set 将对象序列化为JSON并存入Redis
 func (c *JSONCacher[T, K]) Set(ctx context.Context, key K, value T) error {
+	ctx, op := traceCache(ctx, "set", c.prefix)
 	cacheKey := c.prefix + ":" + key.GenKey()
-	return SetJSON(ctx, cacheKey, value, c.expire)
+	err := SetJSON(ctx, cacheKey, value, c.expire)
+	endCache(op, false, err)
+	return err
 }
 
 // GetAndDelete 从Redis获取并反序列化JSON对象
 func (c *JSONCacher[T, K]) GetAndDelete(ctx context.Context, key K) (T, error) {
+	ctx, op := traceCache(ctx, "get_and_delete", c.prefix)
 	result := getEntity[T]()
 	cacheKey := c.prefix + ":" + key.GenKey()
 	err := GetJSON(ctx, cacheKey, &result)
 	if err == nil {
 		Client.Del(ctx, cacheKey)
 	}
+	endCache(op, true, err)
 	return result, err
 }
 
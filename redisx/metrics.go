@@ -0,0 +1,63 @@
+package redisx
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Gong-Yang/g-micor/metricx"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	metricx.Register(mqCollector{})
+}
+
+var (
+	mqLag = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "mq", "lag"),
+		"消费组尚未读取的消息数，无法计算时为 -1", []string{"stream", "group"}, nil)
+	mqPending = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "mq", "pending"),
+		"消费组已读取未 ACK 的消息数", []string{"stream", "group"}, nil)
+	mqConsumers = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "mq", "consumers"),
+		"消费组的消费者数", []string{"stream", "group"}, nil)
+)
+
+// mqScrapeTimeout 采集单个 stream 消费组信息的超时时间
+const mqScrapeTimeout = 3 * time.Second
+
+// mqCollector 采集本服务创建的 Mq 对应 stream 上各消费组的积压情况，Redis 未初始化时不输出
+type mqCollector struct{}
+
+func (mqCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mqLag
+	ch <- mqPending
+	ch <- mqConsumers
+}
+
+func (mqCollector) Collect(ch chan<- prometheus.Metric) {
+	if Client == nil {
+		return
+	}
+	list := mqs()
+	seen := make(map[string]bool, len(list))
+	for _, m := range list {
+		stream := m.stream()
+		if seen[stream] {
+			continue
+		}
+		seen[stream] = true
+		ctx, cancel := context.WithTimeout(context.Background(), mqScrapeTimeout)
+		groups, err := Client.XInfoGroups(ctx, stream).Result()
+		cancel()
+		if err != nil {
+			// stream 尚未创建时返回 no such key
+			slog.Debug("采集MQ消费组失败", "stream", stream, "error", err)
+			continue
+		}
+		for _, g := range groups {
+			ch <- prometheus.MustNewConstMetric(mqLag, prometheus.GaugeValue, float64(g.Lag), stream, g.Name)
+			ch <- prometheus.MustNewConstMetric(mqPending, prometheus.GaugeValue, float64(g.Pending), stream, g.Name)
+			ch <- prometheus.MustNewConstMetric(mqConsumers, prometheus.GaugeValue, float64(g.Consumers), stream, g.Name)
+		}
+	}
+}
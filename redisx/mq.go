@@ -7,32 +7,46 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Gong-Yang/g-micor/logx"
 	"github.com/Gong-Yang/g-micor/syncx"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/proto"
 )
 
 var ConsumerName string
 
 func NewMq[T proto.Message](stream string, maxLen int64) *Mq[T] {
+	ctx, cancel := context.WithCancel(context.Background())
 	m := &Mq[T]{
-		Stream: stream,
-		MaxLen: maxLen,
-		wg:     &sync.WaitGroup{},
-		stopCh: make(chan struct{}),
-		groups: make(map[string]bool),
+		Stream:   stream,
+		MaxLen:   maxLen,
+		wg:       &sync.WaitGroup{},
+		listenWg: &sync.WaitGroup{},
+		stopCh:   make(chan struct{}),
+		stopOnce: &sync.Once{},
+		ctx:      ctx,
+		cancel:   cancel,
+		groups:   make(map[string]bool),
 	}
 	m.wg.Add(1)
 	initList = append(initList, m)
+	mqLock.Lock()
+	mqList = append(mqList, m)
+	mqLock.Unlock()
 	return m
 }
 
 type Mq[T proto.Message] struct {
-	Stream string
-	MaxLen int64
-	wg     *sync.WaitGroup
-	stopCh chan struct{} // 用于优雅关闭
-	groups map[string]bool
+	Stream   string
+	MaxLen   int64
+	wg       *sync.WaitGroup
+	listenWg *sync.WaitGroup // 监听协程，停止时等待处理中的消息完成
+	stopCh   chan struct{}   // 用于优雅关闭
+	stopOnce *sync.Once
+	ctx      context.Context // 停止时取消，打断阻塞中的读取
+	cancel   context.CancelFunc
+	groups   map[string]bool
 }
 
 func (m *Mq[T]) init() {
@@ -41,36 +55,115 @@ func (m *Mq[T]) init() {
 
 // Stop 停止消息监听
 func (m *Mq[T]) Stop() {
-	close(m.stopCh)
+	m.stopOnce.Do(func() {
+		close(m.stopCh)
+		m.cancel()
+	})
+}
+
+// wait 等待所有监听协程退出
+func (m *Mq[T]) wait() {
+	m.listenWg.Wait()
+}
+
+func (m *Mq[T]) stream() string {
+	return m.Stream
+}
+
+var (
+	mqList []mqStopper
+	// mqLock 保护 mqList，指标采集可能与 NewMq 并发
+	mqLock sync.Mutex
+)
+
+// mqs 已创建的 Mq 的副本
+func mqs() []mqStopper {
+	mqLock.Lock()
+	defer mqLock.Unlock()
+	return append([]mqStopper(nil), mqList...)
+}
+
+type mqStopper interface {
+	Stop()
+	wait()
+	stream() string
+}
+
+// StopMq 停止所有MQ监听，并等待处理中的消息完成，直到ctx超时
+func StopMq(ctx context.Context) {
+	list := mqs()
+	for _, m := range list {
+		m.Stop()
+	}
+	done := make(chan struct{})
+	go func() {
+		for _, m := range list {
+			m.wait()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+		slog.Info("MQ监听已全部停止")
+	case <-ctx.Done():
+		slog.Warn("等待MQ监听停止超时", "error", ctx.Err())
+	}
 }
 
 // Publish  发布消息
 func (m *Mq[T]) Publish(msg T) error {
+	return m.PublishCtx(context.Background(), msg)
+}
+
+// PublishCtx 发布消息，ctx 中的追踪号随消息传递给消费者
+func (m *Mq[T]) PublishCtx(ctx context.Context, msg T) (err error) {
+	ctx, op := publishInstrument.Start(ctx, "publish "+m.Stream, messagingAttrs("publish", m.Stream)...)
+	defer func() { op.End(err) }()
 	data, err := proto.Marshal(msg)
 	if err != nil {
-		slog.Error("消息序列化失败", "stream", m.Stream, "error", err)
+		slog.ErrorContext(ctx, "消息序列化失败", "stream", m.Stream, "error", err)
 		return err
 	}
 
+	values := map[string]any{
+		"data": data,
+	}
+	if traceID := logx.TraceID(ctx); traceID != "" {
+		values[traceField] = traceID
+	}
+	injectMessage(ctx, values)
 	args := &redis.XAddArgs{
 		Stream: m.Stream,
-		Values: map[string]any{
-			"data": data,
-		},
+		Values: values,
 		MaxLen: m.MaxLen,
 	}
 
-	result := Client.XAdd(context.Background(), args)
+	result := Client.XAdd(ctx, args)
 	if result.Err() != nil {
-		slog.Error("发布消息失败", "stream", m.Stream, "error", result.Err())
+		slog.ErrorContext(ctx, "发布消息失败", "stream", m.Stream, "error", result.Err())
 		return result.Err()
 	}
 
 	messageId := result.Val()
-	slog.Info("消息发布成功", "stream", m.Stream, "messageId", messageId)
+	op.Span().SetAttributes(attribute.String("messaging.message.id", messageId))
+	slog.InfoContext(ctx, "消息发布成功", "stream", m.Stream, "messageId", messageId)
 	return nil
 }
 
+// traceField 消息中追踪号的字段名
+const traceField = "traceID"
+
+// messageContext 消费消息的 ctx，使用发布方的追踪号，没有时使用消息ID
+func messageContext(ctx context.Context, msg redis.XMessage) context.Context {
+	traceID, _ := msg.Values[traceField].(string)
+	if traceID == "" {
+		traceID = msg.ID
+	}
+	ctx = logx.WithTraceID(ctx, traceID)
+	ctx = extractMessage(ctx, msg)
+	return logx.AddAttrs(ctx, "messageId", msg.ID)
+}
+
 // Listen 监听消息,并且执行方法
 func (m *Mq[T]) Listen(group string, handler func(ctx context.Context, msg T) error) {
 	if _, ok := m.groups[group]; ok {
@@ -79,7 +172,8 @@ func (m *Mq[T]) Listen(group string, handler func(ctx context.Context, msg T) er
 	m.groups[group] = true
 
 	ctx := context.Background()
-	syncx.GOSafe(ctx, func() {
+	m.listenWg.Add(1)
+	syncx.GoSafeWg(ctx, m.listenWg, func() {
 		m.wg.Wait() // 等待初始化完成
 		err := Client.XGroupCreateMkStream(ctx, m.Stream, group, "$").Err()
 		//"$" 表示从最新的消息开始消费
@@ -110,7 +204,7 @@ func (m *Mq[T]) Listen(group string, handler func(ctx context.Context, msg T) er
 			default:
 			}
 
-			result := Client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			result := Client.XReadGroup(m.ctx, &redis.XReadGroupArgs{
 				Group:    group,
 				Consumer: ConsumerName,
 				Streams:  []string{m.Stream, ">"},
@@ -120,7 +214,7 @@ func (m *Mq[T]) Listen(group string, handler func(ctx context.Context, msg T) er
 
 			if err := result.Err(); err != nil {
 				// 超时无消息时返回 redis.Nil，继续轮询
-				if errors.Is(err, redis.Nil) {
+				if errors.Is(err, redis.Nil) || errors.Is(err, context.Canceled) {
 					continue
 				}
 				slog.Error("读取消息失败", "stream", m.Stream, "group", group, "error", err)
@@ -130,8 +224,7 @@ func (m *Mq[T]) Listen(group string, handler func(ctx context.Context, msg T) er
 			streams := result.Val()
 			for _, s := range streams {
 				for _, msg := range s.Messages {
-					//将group放入上下文
-					msgctx := context.WithValue(ctx, "TraceID", msg.ID)
+					msgctx := messageContext(ctx, msg)
 
 					slog.InfoContext(msgctx, "MQ收到消息", "stream", m.Stream, "group", group)
 					// 将消息内容转换为泛型 T
@@ -151,7 +244,12 @@ func (m *Mq[T]) Listen(group string, handler func(ctx context.Context, msg T) er
 					}
 
 					// 执行用户处理逻辑
-					if err := handler(msgctx, entity); err != nil {
+					msgctx, op := processInstrument.Start(msgctx, "process "+m.Stream,
+						append(messagingAttrs("process", m.Stream), attribute.String("messaging.consumer.group.name", group))...)
+					op.Span().SetAttributes(attribute.String("messaging.message.id", msg.ID))
+					err = handler(msgctx, entity)
+					op.End(err)
+					if err != nil {
 						slog.ErrorContext(msgctx, "消息处理失败", "stream", m.Stream, "group", group, "error", err)
 						// 处理失败不进行 ack，保留在 pending
 						continue
@@ -0,0 +1,67 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gong-Yang/g-micor/otelx"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	cacheInstrument   = otelx.NewInstrument("cache.operation.duration", "缓存操作耗时", trace.SpanKindClient)
+	publishInstrument = otelx.NewInstrument("messaging.client.operation.duration", "消息发布耗时", trace.SpanKindProducer)
+	processInstrument = otelx.NewInstrument("messaging.process.duration", "消息处理耗时", trace.SpanKindConsumer)
+	flightInstrument  = otelx.NewInstrument("singleflight.duration", "SingleFlight 耗时", trace.SpanKindInternal)
+)
+
+// traceCache 缓存操作的埋点，缓存名为 key 前缀
+func traceCache(ctx context.Context, operation, prefix string) (context.Context, *otelx.Op) {
+	return cacheInstrument.Start(ctx, "cache "+operation+" "+prefix,
+		attribute.String("cache.name", prefix),
+		attribute.String("cache.operation", operation))
+}
+
+// endCache 结束缓存操作，读取时记录是否命中，未命中不视为错误
+func endCache(op *otelx.Op, read bool, err error) {
+	if read {
+		op.SetAttributes(attribute.Bool("cache.hit", err == nil))
+		if errors.Is(err, redis.Nil) {
+			err = nil
+		}
+	}
+	op.End(err)
+}
+
+// messagingAttrs 消息操作的属性
+func messagingAttrs(operation, stream string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("messaging.system", "redis"),
+		attribute.String("messaging.operation.name", operation),
+		attribute.String("messaging.destination.name", stream),
+	}
+}
+
+// injectMessage 将 ctx 中的链路上下文写入消息字段
+func injectMessage(ctx context.Context, values map[string]any) {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for k, v := range carrier {
+		values[k] = v
+	}
+}
+
+// extractMessage 从消息字段中读取发布方的链路上下文
+func extractMessage(ctx context.Context, msg redis.XMessage) context.Context {
+	carrier := propagation.MapCarrier{}
+	for k, v := range msg.Values {
+		if s, ok := v.(string); ok {
+			carrier[k] = s
+		}
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
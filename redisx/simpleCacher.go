@@ -19,24 +19,32 @@ type SimpleCacher[T comparable, K CacherKey] struct {
 
 // Get 从Redis获取简单类型数据
 func (c *SimpleCacher[T, K]) Get(ctx context.Context, key K) (T, error) {
+	ctx, op := traceCache(ctx, "get", c.prefix)
 	cacheKey := c.prefix + ":" + key.GenKey()
-	return GetSimple[T](ctx, cacheKey)
+	result, err := GetSimple[T](ctx, cacheKey)
+	endCache(op, true, err)
+	return result, err
 }
 
 // Set 将简单类型数据存入Redis
 func (c *SimpleCacher[T, K]) Set(ctx context.Context, key K, value T) error {
+	ctx, op := traceCache(ctx, "set", c.prefix)
 	cacheKey := c.prefix + ":" + key.GenKey()
-	return SetSimple(ctx, cacheKey, value, c.expire)
+	err := SetSimple(ctx, cacheKey, value, c.expire)
+	endCache(op, false, err)
+	return err
 }
 
 // GetAndDelete 从Redis获取简单类型数据并删除
 func (c *SimpleCacher[T, K]) GetAndDelete(ctx context.Context, key K) (T, error) {
+	ctx, op := traceCache(ctx, "get_and_delete", c.prefix)
 	var result T
 	cacheKey := c.prefix + ":" + key.GenKey()
 	result, err := GetSimple[T](ctx, cacheKey)
 	if err == nil {
 		Client.Del(ctx, cacheKey)
 	}
+	endCache(op, true, err)
 	return result, err
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/Gong-Yang/g-micor/errorx"
 	"github.com/Gong-Yang/g-micor/util/random"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var flightMap = make(map[string]complete)
@@ -65,9 +66,12 @@ func InitSingleFlight() {
 	isInit = true
 
 	go func() {
-		for {
-			msg := <-channel
-			flight := flightMap[msg.Channel]
+		// 连接关闭后 channel 会被关闭，协程随之退出
+		for msg := range channel {
+			flight, ok := flightMap[msg.Channel]
+			if !ok {
+				continue
+			}
 			flight.complete(msg.Payload)
 		}
 	}()
@@ -157,9 +161,17 @@ func GetSetNX(ctx context.Context, key string, setAndReceiver any) (isSet bool,
 	return isSet, err
 }
 func (d *DistributedSingleFlight[T]) Do(ctx context.Context, key string, fn func() (T, error)) (res T, err error) {
+	// role 记录本次调用是执行者，还是等待本地或其他节点的结果
+	role := "leader"
+	ctx, op := flightInstrument.Start(ctx, "singleflight "+d.name, attribute.String("singleflight.name", d.name))
+	defer func() {
+		op.SetAttributes(attribute.String("singleflight.role", role))
+		op.End(err)
+	}()
 	d.lock.Lock()
 	callner, ok := d.callMap[key]
 	if ok { // 本地存在
+		role = "local"
 		d.lock.Unlock()
 		callner.wg.Wait()
 		return callner.Result()
@@ -184,6 +196,7 @@ func (d *DistributedSingleFlight[T]) Do(ctx context.Context, key string, fn func
 	d.lock.Unlock()
 
 	if !isSet { // 已存在其他节点在跑
+		role = "remote"
 		// 防止消息通知丢了，起一个协程每2秒扫一下结果
 		go func() {
 			ticker := time.NewTicker(time.Second * 2)
@@ -0,0 +1,144 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Chain 拦截器链
+// grpc.Server 通过 ServerOptions 使用，生成的本地适配器通过 Unary、ServerStream 等函数使用，
+// 保证模块合并在一个进程内或拆分部署时经过同样的拦截器；客户端拦截器通过 DialOptions 用于服务间调用的连接
+type Chain struct {
+	unary        []grpc.UnaryServerInterceptor
+	stream       []grpc.StreamServerInterceptor
+	unaryClient  []grpc.UnaryClientInterceptor
+	streamClient []grpc.StreamClientInterceptor
+}
+
+// DefaultChain 默认拦截器链，app 启动的 grpc.Server、本地适配器与 discover.Grpc 创建的连接共用
+// 默认传递追踪号，记录 OpenTelemetry span 和耗时以及 Prometheus 指标，未启用 otelx 时埋点为空实现
+var DefaultChain = &Chain{
+	unary:        []grpc.UnaryServerInterceptor{TraceUnaryServer, OtelUnaryServer, MetricsUnaryServer},
+	stream:       []grpc.StreamServerInterceptor{TraceStreamServer, OtelStreamServer, MetricsStreamServer},
+	unaryClient:  []grpc.UnaryClientInterceptor{TraceUnaryClient, OtelUnaryClient, MetricsUnaryClient},
+	streamClient: []grpc.StreamClientInterceptor{TraceStreamClient, OtelStreamClient, MetricsStreamClient},
+}
+
+// UseUnary 追加 unary 拦截器，需在 app.Run 之前调用
+func (c *Chain) UseUnary(interceptors ...grpc.UnaryServerInterceptor) {
+	c.unary = append(c.unary, interceptors...)
+}
+
+// UseStream 追加 stream 拦截器，需在 app.Run 之前调用
+func (c *Chain) UseStream(interceptors ...grpc.StreamServerInterceptor) {
+	c.stream = append(c.stream, interceptors...)
+}
+
+// UseUnaryClient 追加 unary 客户端拦截器，需在创建连接之前调用
+func (c *Chain) UseUnaryClient(interceptors ...grpc.UnaryClientInterceptor) {
+	c.unaryClient = append(c.unaryClient, interceptors...)
+}
+
+// UseStreamClient 追加 stream 客户端拦截器，需在创建连接之前调用
+func (c *Chain) UseStreamClient(interceptors ...grpc.StreamClientInterceptor) {
+	c.streamClient = append(c.streamClient, interceptors...)
+}
+
+// ServerOptions 创建 grpc.Server 时使用的拦截器选项
+func (c *Chain) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(c.unary...),
+		grpc.ChainStreamInterceptor(c.stream...),
+	}
+}
+
+// DialOptions 创建客户端连接时使用的拦截器选项
+func (c *Chain) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(c.unaryClient...),
+		grpc.WithChainStreamInterceptor(c.streamClient...),
+	}
+}
+
+// UseUnary 向默认拦截器链追加 unary 拦截器
+func UseUnary(interceptors ...grpc.UnaryServerInterceptor) {
+	DefaultChain.UseUnary(interceptors...)
+}
+
+// UseStream 向默认拦截器链追加 stream 拦截器
+func UseStream(interceptors ...grpc.StreamServerInterceptor) {
+	DefaultChain.UseStream(interceptors...)
+}
+
+// invokeUnary 依次经过 unary 拦截器后调用 handler
+func (c *Chain) invokeUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	for i := len(c.unary) - 1; i >= 0; i-- {
+		interceptor, next := c.unary[i], handler
+		handler = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	return handler(ctx, req)
+}
+
+// invokeStream 依次经过 stream 拦截器后调用 handler
+func (c *Chain) invokeStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	for i := len(c.stream) - 1; i >= 0; i-- {
+		interceptor, next := c.stream[i], handler
+		handler = func(srv any, ss grpc.ServerStream) error {
+			return interceptor(srv, ss, info, next)
+		}
+	}
+	return handler(srv, ss)
+}
+
+// Unary 经默认拦截器链在本进程内调用 unary 方法
+// method 为完整方法名，如 /user.User/Hello，srv 为服务端实现
+func Unary[Req, Res any](ctx context.Context, method string, srv any, in *Req, handler func(context.Context, *Req) (*Res, error)) (*Res, error) {
+	ctx = serverContext(ctx, method)
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: method}
+	res, err := DefaultChain.invokeUnary(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return handler(ctx, req.(*Req))
+	})
+	if err != nil {
+		return nil, err
+	}
+	out, ok := res.(*Res)
+	if !ok && res != nil {
+		return nil, fmt.Errorf("rpcx: unexpected response type %T", res)
+	}
+	return out, nil
+}
+
+// serverContext 将调用方的 ctx 转为服务端视角：
+// outgoing metadata 转为 incoming metadata，并可通过 grpc.Method 取得方法名
+func serverContext(ctx context.Context, method string) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	ctx = metadata.NewIncomingContext(ctx, md.Copy())
+	ctx = metadata.NewOutgoingContext(ctx, nil)
+	return grpc.NewContextWithServerTransportStream(ctx, localTransportStream{method: method})
+}
+
+// localTransportStream 本地调用的 grpc.ServerTransportStream，header 和 trailer 不会回传给调用方
+type localTransportStream struct {
+	method string
+}
+
+func (s localTransportStream) Method() string {
+	return s.method
+}
+
+func (s localTransportStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s localTransportStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s localTransportStream) SetTrailer(metadata.MD) error {
+	return nil
+}
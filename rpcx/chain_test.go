@@ -0,0 +1,80 @@
+package rpcx
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// countingStream 拦截器包装的 ServerStream，统计发送的消息数
+type countingStream struct {
+	grpc.ServerStream
+	sent int
+}
+
+func (s *countingStream) SendMsg(m any) error {
+	s.sent++
+	return s.ServerStream.SendMsg(m)
+}
+
+func TestChain(t *testing.T) {
+	saved := DefaultChain
+	t.Cleanup(func() { DefaultChain = saved })
+
+	t.Run("unary拦截器取得方法名和incoming metadata", func(t *testing.T) {
+		DefaultChain = &Chain{}
+		var method, user string
+		UseUnary(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			method, _ = grpc.Method(ctx)
+			md, _ := metadata.FromIncomingContext(ctx)
+			if v := md.Get("user"); len(v) > 0 {
+				user = v[0]
+			}
+			return handler(ctx, req)
+		})
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "user", "u1")
+		got, err := Unary(ctx, testMethod, nil, wrapperspb.String("abc"), func(ctx context.Context, in *req) (*res, error) {
+			if md, _ := metadata.FromOutgoingContext(ctx); md.Len() > 0 {
+				t.Errorf("outgoing metadata leaked to server: %v", md)
+			}
+			return wrapperspb.Int64(int64(len(in.Value))), nil
+		})
+		if err != nil || got.Value != 3 {
+			t.Fatalf("Unary() = %v, %v, expected 3", got, err)
+		}
+		if method != testMethod || user != "u1" {
+			t.Errorf("method = %q, user = %q", method, user)
+		}
+	})
+
+	t.Run("stream拦截器替换ServerStream", func(t *testing.T) {
+		DefaultChain = &Chain{}
+		counter := &countingStream{}
+		UseStream(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			counter.ServerStream = ss
+			return handler(srv, counter)
+		})
+		stream, _ := ServerStream(context.Background(), testMethod, nil, wrapperspb.String("ab"), func(in *req, s grpc.ServerStreamingServer[res]) error {
+			for i := range len(in.Value) {
+				if err := s.Send(wrapperspb.Int64(int64(i))); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		for {
+			if _, err := stream.Recv(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("Recv() error = %v", err)
+			}
+		}
+		if counter.sent != 2 {
+			t.Errorf("sent = %d, expected 2", counter.sent)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package rpcx
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gong-Yang/g-micor/metricx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsUnaryServer 按方法记录服务端调用数、耗时和状态码
+func MetricsUnaryServer(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+	observe("server", info.FullMethod, err, start)
+	return res, err
+}
+
+// MetricsStreamServer 按方法记录服务端流的调用数、耗时和状态码
+func MetricsStreamServer(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observe("server", info.FullMethod, err, start)
+	return err
+}
+
+// MetricsUnaryClient 按方法记录客户端调用数、耗时和状态码
+func MetricsUnaryClient(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	observe("client", method, err, start)
+	return err
+}
+
+// MetricsStreamClient 按方法记录客户端流的调用数、耗时和状态码，流结束时记录
+func MetricsStreamClient(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		observe("client", method, err, start)
+		return nil, err
+	}
+	return newEndClientStream(ctx, stream, desc, func(err error) { observe("client", method, err, start) }), nil
+}
+
+func observe(side, method string, err error, start time.Time) {
+	metricx.ObserveRPC(side, method, status.Code(err).String(), time.Since(start))
+}
@@ -0,0 +1,52 @@
+package rpcx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/metricx"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeClientStream 每次读取都成功的客户端流
+type fakeClientStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *fakeClientStream) Context() context.Context { return s.ctx }
+func (s *fakeClientStream) SendMsg(m any) error      { return nil }
+func (s *fakeClientStream) CloseSend() error         { return nil }
+func (s *fakeClientStream) RecvMsg(m any) error      { return nil }
+
+func TestMetricsStreamClient(t *testing.T) {
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{ctx: ctx}, nil
+	}
+	stream, err := MetricsStreamClient(context.Background(), &grpc.StreamDesc{ClientStreams: true}, nil, "/test.Test/Upload", streamer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.SendMsg(wrapperspb.String("a"))
+	stream.CloseSend()
+	if err = stream.RecvMsg(&res{}); err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	metricx.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		`gmicor_rpc_requests_total{code="OK",method="/test.Test/Upload",side="client"} 1`,
+		`gmicor_rpc_duration_seconds_count{method="/test.Test/Upload",side="client"} 1`,
+	} {
+		t.Run(want, func(t *testing.T) {
+			if !strings.Contains(w.Body.String(), want) {
+				t.Errorf("客户端流成功读取响应后应记录指标 %s", want)
+			}
+		})
+	}
+}
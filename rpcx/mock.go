@@ -0,0 +1,47 @@
+package rpcx
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockCalls 生成的 XxxMock 的调用记录，并发安全
+type MockCalls struct {
+	lock  sync.Mutex
+	calls map[string][]any
+}
+
+// Record 记录一次调用，流式客户端方法没有请求，in 为 nil
+func (c *MockCalls) Record(method string, in any) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string][]any)
+	}
+	c.calls[method] = append(c.calls[method], in)
+}
+
+// Count 方法被调用的次数
+func (c *MockCalls) Count(method string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.calls[method])
+}
+
+// Calls 方法每次被调用时的请求，按调用顺序
+func Calls[T any](c *MockCalls, method string) []T {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	res := make([]T, 0, len(c.calls[method]))
+	for _, in := range c.calls[method] {
+		res = append(res, in.(T))
+	}
+	return res
+}
+
+// NotMocked 调用未设置的 mock 方法时返回的错误
+func NotMocked(method string) error {
+	return status.Errorf(codes.Unimplemented, "rpcx: method %s not mocked", method)
+}
@@ -0,0 +1,158 @@
+package rpcx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/Gong-Yang/g-micor/otelx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	serverInstrument = otelx.NewInstrument("rpc.server.duration", "rpc 服务端耗时", trace.SpanKindServer)
+	clientInstrument = otelx.NewInstrument("rpc.client.duration", "rpc 客户端耗时", trace.SpanKindClient)
+)
+
+// OtelUnaryServer 记录服务端 span 和耗时，调用方的链路上下文从 incoming metadata 中读取，本地调用时沿用调用方 ctx 中的 span
+func OtelUnaryServer(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, op := startServer(ctx, info.FullMethod)
+	res, err := handler(ctx, req)
+	endRPC(op, err)
+	return res, err
+}
+
+// OtelStreamServer 记录服务端 span 和耗时
+func OtelStreamServer(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, op := startServer(ss.Context(), info.FullMethod)
+	err := handler(srv, &ctxServerStream{ServerStream: ss, ctx: ctx})
+	endRPC(op, err)
+	return err
+}
+
+// OtelUnaryClient 记录客户端 span 和耗时，并将链路上下文写入 outgoing metadata
+func OtelUnaryClient(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, op := startClient(ctx, method)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	endRPC(op, err)
+	return err
+}
+
+// OtelStreamClient 记录客户端 span 和耗时，流结束时结束 span
+func OtelStreamClient(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, op := startClient(ctx, method)
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		endRPC(op, err)
+		return nil, err
+	}
+	return newEndClientStream(ctx, stream, desc, func(err error) { endRPC(op, err) }), nil
+}
+
+func startServer(ctx context.Context, method string) (context.Context, *otelx.Op) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	return serverInstrument.Start(ctx, strings.TrimPrefix(method, "/"), rpcAttrs(method)...)
+}
+
+func startClient(ctx context.Context, method string) (context.Context, *otelx.Op) {
+	ctx, op := clientInstrument.Start(ctx, strings.TrimPrefix(method, "/"), rpcAttrs(method)...)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), op
+}
+
+// endRPC 结束 span，记录 grpc 状态码
+func endRPC(op *otelx.Op, err error) {
+	op.SetAttributes(attribute.String("rpc.grpc.status_code", status.Code(err).String()))
+	op.End(err)
+}
+
+// rpcAttrs 完整方法名 /pkg.Service/Method 对应的属性
+func rpcAttrs(method string) []attribute.KeyValue {
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", name),
+	}
+}
+
+// metadataCarrier 以 grpc metadata 作为链路上下文的载体
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	return first(metadata.MD(c).Get(key))
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// endClientStream 在客户端流结束时调用一次 end：
+// 读到 EOF 或错误、非服务端流(一元返回)的一次成功读取、发送出错，以及调用方 ctx 结束
+type endClientStream struct {
+	grpc.ClientStream
+	serverStreams bool
+	end           func(err error)
+	once          sync.Once
+	done          chan struct{}
+}
+
+func newEndClientStream(ctx context.Context, stream grpc.ClientStream, desc *grpc.StreamDesc, end func(err error)) *endClientStream {
+	s := &endClientStream{ClientStream: stream, serverStreams: desc.ServerStreams, end: end, done: make(chan struct{})}
+	go func() {
+		select {
+		case <-stream.Context().Done():
+			// 调用方取消或超时，流可能不会再被读取
+			if err := ctx.Err(); err != nil {
+				s.finish(status.FromContextError(err).Err())
+			}
+		case <-s.done:
+		}
+	}()
+	return s
+}
+
+func (s *endClientStream) finish(err error) {
+	s.once.Do(func() {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		s.end(err)
+		close(s.done)
+	})
+}
+
+func (s *endClientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	// io.EOF 表示流已结束，状态由 RecvMsg 返回
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *endClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil || !s.serverStreams {
+		s.finish(err)
+	}
+	return err
+}
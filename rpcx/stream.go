@@ -0,0 +1,324 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// ServerStream 经默认拦截器链在本进程内调用 server-streaming 方法
+// handler 在独立协程中执行，返回后客户端 Recv 得到 io.EOF 或 handler 返回的错误
+func ServerStream[Req, Res any](ctx context.Context, method string, srv any, in *Req, handler func(*Req, grpc.ServerStreamingServer[Res]) error) (grpc.ServerStreamingClient[Res], error) {
+	p := newPipe[Req, Res](ctx, method)
+	p.closeSend()
+	info := &grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}
+	go p.run(func() error {
+		return p.serve(srv, info, func(stream serverStream[Req, Res]) error {
+			return handler(in, stream)
+		})
+	})
+	return &clientSide[Req, Res]{p}, nil
+}
+
+// ClientStream 经默认拦截器链在本进程内调用 client-streaming 方法
+func ClientStream[Req, Res any](ctx context.Context, method string, srv any, handler func(grpc.ClientStreamingServer[Req, Res]) error) (grpc.ClientStreamingClient[Req, Res], error) {
+	p := newPipe[Req, Res](ctx, method)
+	info := &grpc.StreamServerInfo{FullMethod: method, IsClientStream: true}
+	go p.run(func() error {
+		return p.serve(srv, info, func(stream serverStream[Req, Res]) error {
+			return handler(stream)
+		})
+	})
+	return &clientSide[Req, Res]{p}, nil
+}
+
+// BidiStream 经默认拦截器链在本进程内调用双向流方法
+func BidiStream[Req, Res any](ctx context.Context, method string, srv any, handler func(grpc.BidiStreamingServer[Req, Res]) error) (grpc.BidiStreamingClient[Req, Res], error) {
+	p := newPipe[Req, Res](ctx, method)
+	info := &grpc.StreamServerInfo{FullMethod: method, IsClientStream: true, IsServerStream: true}
+	go p.run(func() error {
+		return p.serve(srv, info, func(stream serverStream[Req, Res]) error {
+			return handler(stream)
+		})
+	})
+	return &clientSide[Req, Res]{p}, nil
+}
+
+// serverStream 服务端流，serverSide 与 grpc.GenericServerStream 均实现
+type serverStream[Req, Res any] interface {
+	grpc.ServerStream
+	Send(*Res) error
+	SendAndClose(*Res) error
+	Recv() (*Req, error)
+}
+
+// pipe 连接本地调用的客户端与服务端
+type pipe[Req, Res any] struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	serverCtx context.Context // 服务端视角的 ctx，见 serverContext
+
+	requests  chan *Req     // 客户端 -> 服务端
+	responses chan *Res     // 服务端 -> 客户端
+	sendDone  chan struct{} // 客户端已调用 CloseSend
+	closeOnce sync.Once
+	done      chan struct{} // 服务端 handler 已返回
+	err       error         // handler 返回的错误，done 关闭后可读
+
+	lock       sync.Mutex
+	header     metadata.MD
+	trailer    metadata.MD
+	headerSent chan struct{}
+	headerOnce sync.Once
+}
+
+func newPipe[Req, Res any](ctx context.Context, method string) *pipe[Req, Res] {
+	ctx, cancel := context.WithCancel(ctx)
+	return &pipe[Req, Res]{
+		ctx:        ctx,
+		cancel:     cancel,
+		serverCtx:  serverContext(ctx, method),
+		requests:   make(chan *Req),
+		responses:  make(chan *Res),
+		sendDone:   make(chan struct{}),
+		done:       make(chan struct{}),
+		headerSent: make(chan struct{}),
+	}
+}
+
+// run 执行服务端 handler，handler 中的 panic 转为错误返回给客户端
+// handler 返回后取消 ctx，与 grpc 中服务端方法返回即结束调用一致
+func (p *pipe[Req, Res]) run(handler func() error) {
+	defer p.cancel()
+	defer close(p.done)
+	defer p.sendHeader()
+	defer func() {
+		if r := recover(); r != nil {
+			p.err = fmt.Errorf("rpcx: stream handler panic: %v", r)
+		}
+	}()
+	p.err = handler()
+}
+
+// serve 经默认拦截器链执行 handler，拦截器替换过的 ServerStream 经 grpc.GenericServerStream 转为类型化的流
+func (p *pipe[Req, Res]) serve(srv any, info *grpc.StreamServerInfo, handler func(serverStream[Req, Res]) error) error {
+	ss := &serverSide[Req, Res]{p}
+	return DefaultChain.invokeStream(srv, ss, info, func(_ any, stream grpc.ServerStream) error {
+		if typed, ok := stream.(serverStream[Req, Res]); ok {
+			return handler(typed)
+		}
+		return handler(&grpc.GenericServerStream[Req, Res]{ServerStream: stream})
+	})
+}
+
+// result handler 结束时客户端得到的错误
+func (p *pipe[Req, Res]) result() error {
+	if p.err != nil {
+		return p.err
+	}
+	return io.EOF
+}
+
+// ctxErr ctx 结束时，如果 handler 已返回则以 handler 的结果为准
+func (p *pipe[Req, Res]) ctxErr() error {
+	select {
+	case <-p.done:
+		return p.result()
+	default:
+		return p.ctx.Err()
+	}
+}
+
+func (p *pipe[Req, Res]) closeSend() {
+	p.closeOnce.Do(func() {
+		close(p.sendDone)
+	})
+}
+
+func (p *pipe[Req, Res]) sendHeader() {
+	p.headerOnce.Do(func() {
+		close(p.headerSent)
+	})
+}
+
+// clientSide 客户端视角，实现 grpc.ServerStreamingClient/ClientStreamingClient/BidiStreamingClient
+type clientSide[Req, Res any] struct {
+	p *pipe[Req, Res]
+}
+
+func (c *clientSide[Req, Res]) Send(req *Req) error {
+	select {
+	case c.p.requests <- req:
+		return nil
+	case <-c.p.sendDone:
+		return io.EOF
+	case <-c.p.done:
+		// 服务端已结束，错误由 Recv 返回
+		return io.EOF
+	case <-c.p.ctx.Done():
+		return c.p.ctx.Err()
+	}
+}
+
+func (c *clientSide[Req, Res]) Recv() (*Res, error) {
+	select {
+	case res := <-c.p.responses:
+		return res, nil
+	case <-c.p.done:
+		return nil, c.p.result()
+	case <-c.p.ctx.Done():
+		return nil, c.p.ctxErr()
+	}
+}
+
+func (c *clientSide[Req, Res]) CloseAndRecv() (*Res, error) {
+	c.p.closeSend()
+	res, err := c.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("rpcx: stream handler returned without SendAndClose")
+		}
+		return nil, err
+	}
+	// 等待 handler 结束，确保错误和 trailer 可见
+	<-c.p.done
+	return res, c.p.err
+}
+
+func (c *clientSide[Req, Res]) Header() (metadata.MD, error) {
+	select {
+	case <-c.p.headerSent:
+	case <-c.p.ctx.Done():
+		if err := c.p.ctxErr(); err != io.EOF {
+			return nil, err
+		}
+	}
+	c.p.lock.Lock()
+	defer c.p.lock.Unlock()
+	return c.p.header.Copy(), nil
+}
+
+func (c *clientSide[Req, Res]) Trailer() metadata.MD {
+	c.p.lock.Lock()
+	defer c.p.lock.Unlock()
+	return c.p.trailer.Copy()
+}
+
+func (c *clientSide[Req, Res]) CloseSend() error {
+	c.p.closeSend()
+	return nil
+}
+
+func (c *clientSide[Req, Res]) Context() context.Context {
+	return c.p.ctx
+}
+
+func (c *clientSide[Req, Res]) SendMsg(m any) error {
+	req, ok := m.(*Req)
+	if !ok {
+		return fmt.Errorf("rpcx: unexpected message type %T", m)
+	}
+	return c.Send(req)
+}
+
+func (c *clientSide[Req, Res]) RecvMsg(m any) error {
+	res, err := c.Recv()
+	if err != nil {
+		return err
+	}
+	return copyMsg(m, res)
+}
+
+// serverSide 服务端视角，实现 grpc.ServerStreamingServer/ClientStreamingServer/BidiStreamingServer
+type serverSide[Req, Res any] struct {
+	p *pipe[Req, Res]
+}
+
+func (s *serverSide[Req, Res]) Send(res *Res) error {
+	s.p.sendHeader()
+	select {
+	case s.p.responses <- res:
+		return nil
+	case <-s.p.ctx.Done():
+		return s.p.ctx.Err()
+	}
+}
+
+func (s *serverSide[Req, Res]) SendAndClose(res *Res) error {
+	return s.Send(res)
+}
+
+func (s *serverSide[Req, Res]) Recv() (*Req, error) {
+	select {
+	case req := <-s.p.requests:
+		return req, nil
+	case <-s.p.sendDone:
+		// CloseSend 之前已发送的消息需先被接收
+		select {
+		case req := <-s.p.requests:
+			return req, nil
+		default:
+			return nil, io.EOF
+		}
+	case <-s.p.ctx.Done():
+		return nil, s.p.ctx.Err()
+	}
+}
+
+func (s *serverSide[Req, Res]) SetHeader(md metadata.MD) error {
+	s.p.lock.Lock()
+	defer s.p.lock.Unlock()
+	s.p.header = metadata.Join(s.p.header, md)
+	return nil
+}
+
+func (s *serverSide[Req, Res]) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	s.p.sendHeader()
+	return nil
+}
+
+func (s *serverSide[Req, Res]) SetTrailer(md metadata.MD) {
+	s.p.lock.Lock()
+	defer s.p.lock.Unlock()
+	s.p.trailer = metadata.Join(s.p.trailer, md)
+}
+
+func (s *serverSide[Req, Res]) Context() context.Context {
+	return s.p.serverCtx
+}
+
+func (s *serverSide[Req, Res]) SendMsg(m any) error {
+	res, ok := m.(*Res)
+	if !ok {
+		return fmt.Errorf("rpcx: unexpected message type %T", m)
+	}
+	return s.Send(res)
+}
+
+func (s *serverSide[Req, Res]) RecvMsg(m any) error {
+	req, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	return copyMsg(m, req)
+}
+
+// copyMsg 将 src 的内容复制到 dst，用于 RecvMsg
+func copyMsg(dst, src any) error {
+	d, ok1 := dst.(proto.Message)
+	s, ok2 := src.(proto.Message)
+	if !ok1 || !ok2 || d.ProtoReflect().Descriptor() != s.ProtoReflect().Descriptor() {
+		return fmt.Errorf("rpcx: unexpected message type %T", dst)
+	}
+	proto.Reset(d)
+	proto.Merge(d, s)
+	return nil
+}
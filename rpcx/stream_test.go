@@ -0,0 +1,109 @@
+package rpcx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type (
+	req = wrapperspb.StringValue
+	res = wrapperspb.Int64Value
+)
+
+const testMethod = "/test.Test/Method"
+
+func TestServerStream(t *testing.T) {
+	t.Run("按顺序接收后得到EOF", func(t *testing.T) {
+		stream, _ := ServerStream(context.Background(), testMethod, nil, wrapperspb.String("abc"), func(in *req, s grpc.ServerStreamingServer[res]) error {
+			for i := range len(in.Value) {
+				if err := s.Send(wrapperspb.Int64(int64(i))); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		for i := range 3 {
+			got, err := stream.Recv()
+			if err != nil || got.Value != int64(i) {
+				t.Fatalf("Recv() = %v, %v, expected %d", got, err, i)
+			}
+		}
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Errorf("Recv() error = %v, expected io.EOF", err)
+		}
+	})
+
+	t.Run("返回handler的错误", func(t *testing.T) {
+		expected := errors.New("boom")
+		stream, _ := ServerStream(context.Background(), testMethod, nil, wrapperspb.String(""), func(in *req, s grpc.ServerStreamingServer[res]) error {
+			return expected
+		})
+		if _, err := stream.Recv(); err != expected {
+			t.Errorf("Recv() error = %v, expected %v", err, expected)
+		}
+	})
+}
+
+func TestClientStream(t *testing.T) {
+	t.Run("汇总客户端发送的消息", func(t *testing.T) {
+		stream, _ := ClientStream(context.Background(), testMethod, nil, func(s grpc.ClientStreamingServer[req, res]) error {
+			var total int64
+			for {
+				in, err := s.Recv()
+				if err == io.EOF {
+					return s.SendAndClose(wrapperspb.Int64(total))
+				}
+				if err != nil {
+					return err
+				}
+				total += int64(len(in.Value))
+			}
+		})
+		for _, v := range []string{"a", "bb", "ccc"} {
+			if err := stream.Send(wrapperspb.String(v)); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+		}
+		got, err := stream.CloseAndRecv()
+		if err != nil || got.Value != 6 {
+			t.Errorf("CloseAndRecv() = %v, %v, expected 6", got, err)
+		}
+	})
+}
+
+func TestBidiStream(t *testing.T) {
+	t.Run("逐条应答", func(t *testing.T) {
+		stream, _ := BidiStream(context.Background(), testMethod, nil, func(s grpc.BidiStreamingServer[req, res]) error {
+			for {
+				in, err := s.Recv()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if err = s.Send(wrapperspb.Int64(int64(len(in.Value)))); err != nil {
+					return err
+				}
+			}
+		})
+		for _, v := range []string{"a", "bb"} {
+			if err := stream.Send(wrapperspb.String(v)); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			got, err := stream.Recv()
+			if err != nil || got.Value != int64(len(v)) {
+				t.Fatalf("Recv() = %v, %v, expected %d", got, err, len(v))
+			}
+		}
+		stream.CloseSend()
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Errorf("Recv() error = %v, expected io.EOF", err)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package rpcx
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Gong-Yang/g-micor/logx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TraceUnaryClient 将 context 中的追踪号放入 outgoing metadata
+func TraceUnaryClient(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(traceOutgoing(ctx), method, req, reply, cc, opts...)
+}
+
+// TraceStreamClient 将 context 中的追踪号放入 outgoing metadata
+func TraceStreamClient(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(traceOutgoing(ctx), desc, cc, method, opts...)
+}
+
+// TraceUnaryServer 从 incoming metadata 中取得调用方的追踪号放入 context，没有时生成
+func TraceUnaryServer(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(traceIncoming(ctx), req)
+}
+
+// TraceStreamServer 从 incoming metadata 中取得调用方的追踪号放入 context，没有时生成
+func TraceStreamServer(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &ctxServerStream{ServerStream: ss, ctx: traceIncoming(ss.Context())})
+}
+
+// traceOutgoing 调用方未设置追踪号时，将 context 中的追踪号放入 outgoing metadata
+func traceOutgoing(ctx context.Context) context.Context {
+	traceID := logx.TraceID(ctx)
+	if traceID == "" {
+		return ctx
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if len(md.Get(logx.HeaderTraceparent)) > 0 || len(md.Get(logx.HeaderRequestID)) > 0 {
+		return ctx
+	}
+	kv := []string{strings.ToLower(logx.HeaderRequestID), traceID}
+	if traceparent := logx.Traceparent(traceID); traceparent != "" {
+		kv = append(kv, logx.HeaderTraceparent, traceparent)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// traceIncoming 优先使用 metadata 中的追踪号；本地调用时 metadata 中没有，沿用调用方 context 中的追踪号
+func traceIncoming(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	traceID := logx.IncomingTraceID(first(md.Get(logx.HeaderTraceparent)), first(md.Get(logx.HeaderRequestID)))
+	if traceID == "" {
+		if logx.TraceID(ctx) != "" {
+			return ctx
+		}
+		traceID = logx.NewTraceID()
+	}
+	return logx.WithTraceID(ctx, traceID)
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// ctxServerStream 替换了 context 的 ServerStream
+type ctxServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *ctxServerStream) Context() context.Context {
+	return s.ctx
+}
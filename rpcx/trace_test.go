@@ -0,0 +1,46 @@
+package rpcx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Gong-Yang/g-micor/logx"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestTrace(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := logx.WithTraceID(context.Background(), traceID)
+
+	t.Run("本地调用沿用调用方的追踪号", func(t *testing.T) {
+		var got string
+		_, err := Unary(ctx, testMethod, nil, wrapperspb.String("abc"), func(ctx context.Context, in *req) (*res, error) {
+			got = logx.TraceID(ctx)
+			return wrapperspb.Int64(0), nil
+		})
+		if err != nil || got != traceID {
+			t.Errorf("trace id = %q, err = %v", got, err)
+		}
+	})
+	t.Run("客户端写入metadata", func(t *testing.T) {
+		md, _ := metadata.FromOutgoingContext(traceOutgoing(ctx))
+		if got := logx.ParseTraceparent(first(md.Get("traceparent"))); got != traceID {
+			t.Errorf("traceparent trace id = %q, md = %v", got, md)
+		}
+		if got := first(md.Get("x-request-id")); got != traceID {
+			t.Errorf("x-request-id = %q", got)
+		}
+	})
+	t.Run("服务端读取metadata", func(t *testing.T) {
+		in := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1"))
+		if got := logx.TraceID(traceIncoming(in)); got != "req-1" {
+			t.Errorf("trace id = %q", got)
+		}
+	})
+	t.Run("没有追踪号时生成", func(t *testing.T) {
+		if got := logx.TraceID(traceIncoming(context.Background())); len(got) != 32 {
+			t.Errorf("trace id = %q", got)
+		}
+	})
+}
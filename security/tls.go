@@ -0,0 +1,86 @@
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TLSConfig 证书配置，未配置证书时使用明文传输
+// 配置了 CAFile 时服务端校验客户端证书、客户端校验服务端证书，即双向TLS
+type TLSConfig struct {
+	CertFile   string `yaml:"certFile"`   // 本端证书
+	KeyFile    string `yaml:"keyFile"`    // 本端私钥
+	CAFile     string `yaml:"caFile"`     // 用于校验对端证书的CA
+	ServerName string `yaml:"serverName"` // 校验服务端证书时使用的名称，默认取连接地址的主机名
+}
+
+// Enabled 是否配置了证书
+func (c *TLSConfig) Enabled() bool {
+	return c != nil && c.CertFile != ""
+}
+
+// ServerCredentials 服务端使用的传输凭证
+func (c *TLSConfig) ServerCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load tls key pair: %w", err)
+	}
+	conf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.ClientCAs = pool
+		conf.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(conf), nil
+}
+
+// ClientCredentials 客户端使用的传输凭证
+func (c *TLSConfig) ClientCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load tls key pair: %w", err)
+	}
+	conf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ServerName:   c.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootCAs = pool
+	}
+	return credentials.NewTLS(conf), nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("load tls ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.New("load tls ca: no certificate found in " + file)
+	}
+	return pool, nil
+}
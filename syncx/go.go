@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 )
 
 // GOSafe 安全协程 内部panic不会导致整个应用挂掉
@@ -35,38 +36,76 @@ func GoSafeWg(ctx context.Context, wg *sync.WaitGroup, f func()) {
 type Task func()
 
 type WorkerPool struct {
-	tasks chan Task
-	wg    sync.WaitGroup
-	ctx   context.Context
+	tasks   chan Task
+	wg      sync.WaitGroup
+	ctx     context.Context
+	name    string
+	size    int
+	queued  atomic.Int64 // 已提交等待 worker 接收的任务数
+	running atomic.Int64 // 执行中的任务数
 }
 
 // NewWorkerPool 创建一个线程池，size 是池的大小（goroutine 数量）
 func NewWorkerPool(ctx context.Context, size int) *WorkerPool {
+	return NewNamedWorkerPool(ctx, "", size)
+}
+
+// NewNamedWorkerPool 创建有名字的线程池，name 不为空时通过 metricx 暴露队列深度，Close 后移除
+func NewNamedWorkerPool(ctx context.Context, name string, size int) *WorkerPool {
 	pool := &WorkerPool{
 		tasks: make(chan Task),
 		ctx:   ctx,
+		name:  name,
+		size:  size,
 	}
 	// 启动指定数量的 worker goroutine
 	for i := 0; i < size; i++ {
 		GOSafe(ctx, pool.worker)
 	}
+	if name != "" {
+		pools.Store(pool, struct{}{})
+	}
 	return pool
 }
 
 // worker 是每个 goroutine 的执行逻辑
 func (p *WorkerPool) worker() {
 	for task := range p.tasks {
-		task()      // 执行任务
-		p.wg.Done() // 任务完成，减少 WaitGroup 计数器
+		p.queued.Add(-1)
+		p.run(task)
 	}
 }
 
+// run 执行任务，任务 panic 时同样更新计数，worker 继续接收后续任务
+func (p *WorkerPool) run(task Task) {
+	p.running.Add(1)
+	defer p.running.Add(-1)
+	defer p.wg.Done() // 任务完成，减少 WaitGroup 计数器
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(p.ctx, "worker task panic", "pool", p.name, "err", r, "panic", string(debug.Stack()))
+		}
+	}()
+	task()
+}
+
 // Submit 提交任务到线程池
 func (p *WorkerPool) Submit(task Task) {
 	p.wg.Add(1) // 增加 WaitGroup 计数器
+	p.queued.Add(1)
 	p.tasks <- task
 }
 
+// Queued 已提交等待执行的任务数
+func (p *WorkerPool) Queued() int64 {
+	return p.queued.Load()
+}
+
+// Running 执行中的任务数
+func (p *WorkerPool) Running() int64 {
+	return p.running.Load()
+}
+
 // Wait 等待所有任务完成
 func (p *WorkerPool) Wait() {
 	p.wg.Wait() // 阻塞，直到 WaitGroup 计数器归零
@@ -74,5 +113,6 @@ func (p *WorkerPool) Wait() {
 
 // Close 关闭线程池
 func (p *WorkerPool) Close() {
+	pools.Delete(p)
 	close(p.tasks) // 关闭任务通道，停止所有 worker goroutine
 }
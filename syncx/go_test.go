@@ -0,0 +1,30 @@
+package syncx
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWorkerPoolPanic(t *testing.T) {
+	pool := NewWorkerPool(context.Background(), 1)
+	defer pool.Close()
+	pool.Submit(func() { panic("boom") })
+	pool.Wait()
+
+	t.Run("panic后计数归零", func(t *testing.T) {
+		if running, queued := pool.Running(), pool.Queued(); running != 0 || queued != 0 {
+			t.Errorf("Running() = %d, Queued() = %d, want 0", running, queued)
+		}
+	})
+	t.Run("worker继续执行后续任务", func(t *testing.T) {
+		var done atomic.Int64
+		for range 3 {
+			pool.Submit(func() { done.Add(1) })
+		}
+		pool.Wait()
+		if got := done.Load(); got != 3 {
+			t.Errorf("done = %d, want 3", got)
+		}
+	})
+}
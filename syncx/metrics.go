@@ -0,0 +1,56 @@
+package syncx
+
+import (
+	"sync"
+
+	"github.com/Gong-Yang/g-micor/metricx"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pools 有名字的线程池
+var pools sync.Map
+
+func init() {
+	metricx.Register(poolCollector{})
+}
+
+var (
+	poolQueued = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "worker_pool", "queued"),
+		"线程池已提交等待执行的任务数", []string{"pool"}, nil)
+	poolRunning = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "worker_pool", "running"),
+		"线程池执行中的任务数", []string{"pool"}, nil)
+	poolSize = prometheus.NewDesc(prometheus.BuildFQName(metricx.Namespace, "worker_pool", "size"),
+		"线程池的 worker 数", []string{"pool"}, nil)
+)
+
+// poolCollector 采集有名字的线程池的队列深度
+type poolCollector struct{}
+
+func (poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolQueued
+	ch <- poolRunning
+	ch <- poolSize
+}
+
+// Collect 同名的线程池合并输出
+func (poolCollector) Collect(ch chan<- prometheus.Metric) {
+	type stat struct{ queued, running, size int64 }
+	stats := make(map[string]*stat)
+	pools.Range(func(key, _ any) bool {
+		p := key.(*WorkerPool)
+		s, ok := stats[p.name]
+		if !ok {
+			s = &stat{}
+			stats[p.name] = s
+		}
+		s.queued += p.Queued()
+		s.running += p.Running()
+		s.size += int64(p.size)
+		return true
+	})
+	for name, s := range stats {
+		ch <- prometheus.MustNewConstMetric(poolQueued, prometheus.GaugeValue, float64(s.queued), name)
+		ch <- prometheus.MustNewConstMetric(poolRunning, prometheus.GaugeValue, float64(s.running), name)
+		ch <- prometheus.MustNewConstMetric(poolSize, prometheus.GaugeValue, float64(s.size), name)
+	}
+}